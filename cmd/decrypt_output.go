@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/cipher"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/version"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// EncryptedFileExt is the file extension appended to the encrypted csv output file.
+const EncryptedFileExt = ".enc"
+
+var (
+	EncryptedFile string
+	// outputPassphrase is the password of encrypted csv output file, output is plaintext if empty.
+	outputPassphrase string
+)
+
+var decryptOutputCmd = &cobra.Command{
+	Use:   "decrypt-output",
+	Short: "Decrypt the encrypted recovery csv output file and print it on stdout",
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		// stdout is kept for the decrypted csv
+		fmt.Fprintln(os.Stderr, "Version: "+version.TextVersion()+"\n")
+		decryptOutput()
+	},
+}
+
+func decryptOutput() {
+	envelopeBytes, err := os.ReadFile(filepath.Clean(EncryptedFile))
+	if err != nil {
+		log.Fatalf("Read encrypted file %v failed: %v", EncryptedFile, err)
+	}
+	envelope := &cipher.Envelope{}
+	if err := json.Unmarshal(envelopeBytes, envelope); err != nil {
+		log.Fatalf("Parse encrypted file %v failed: %v", EncryptedFile, err)
+	}
+
+	passphrase := readPassphrase(passphraseProvider(OutputPassphraseSource), OutputPassphraseName,
+		fmt.Sprintf("Enter password to decrypt %v", EncryptedFile))
+	data, err := envelope.Open(passphrase)
+	if err != nil {
+		log.Fatalf("Decrypt %v failed: %v", EncryptedFile, err)
+	}
+	if _, err := os.Stdout.Write(data); err != nil {
+		log.Fatalf("Write decrypted data failed: %v", err)
+	}
+}
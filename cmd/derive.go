@@ -1,11 +1,10 @@
 package cmd
 
 import (
-	"fmt"
+	"strings"
 
 	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
 	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/wallet"
-	"github.com/CoboGlobal/cobo-mpc-recovery-kits/version"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -15,8 +14,10 @@ var deriveCmd = &cobra.Command{
 	Short: "Root key derives the corresponding child public key and addresses based on the paths and token",
 	Args:  cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Version: " + version.TextVersion() + "\n")
+		initOutput()
+		printVersion()
 		derive()
+		flushRecords()
 	},
 }
 
@@ -28,6 +29,9 @@ func derive() {
 	if err != nil {
 		log.Fatalf("failed to deserialize root key: %v", RootKey)
 	}
+	loadTokenRegistry()
+	checkExtendedKeyVersion()
+	emitRecord(&Record{Type: RecordRoot, RootExtendedPublicKey: extendedKeyString(key.PublicKey())})
 
 	if len(Paths) > 0 {
 		for _, hdPath := range Paths {
@@ -35,22 +39,107 @@ func derive() {
 			if err != nil {
 				log.Fatalf("Derive path %v error: %v", hdPath, err)
 			}
-			log.Printf("Path: %v derived child extended public key: %v", hdPath, dk.PublicKey().String())
+			log.Printf("Path: %v derived child extended public key: %v", hdPath, extendedKeyString(dk.PublicKey()))
+			record := &Record{Type: RecordKey, Path: hdPath, ExtendedPublicKey: extendedKeyString(dk.PublicKey()), Token: Token}
 
 			if Token != "" {
-				token, err := wallet.GetToken(Token)
-				if err != nil {
-					log.Fatalf("Get token error: %v", err)
-				}
-
-				addresses, err := token.GenerateAddresses(dk)
+				addresses, err := tokenAddresses(Token, dk)
 				if err != nil {
 					log.Fatalf("Generate address error: %v", err)
 				}
 				for _, address := range addresses {
-					log.Printf("Token %v Address Type: %v, Address: %v", token, address.Type, address.Address)
+					log.Printf("Token %v Address Type: %v, Address: %v", Token, address.Type, address.Address)
 				}
+				record.Addresses = recordAddresses(addresses)
 			}
+			record.Descriptors = recordDescriptors(tokenDescriptors(key, hdPath))
+			emitRecord(record)
+		}
+	}
+}
+
+// loadTokenRegistry registers tokens from token registry file and checks the token flag.
+func loadTokenRegistry() {
+	if TokenRegistry != "" {
+		if err := wallet.LoadTokenRegistry(TokenRegistry); err != nil {
+			log.Fatalf("Load token registry error: %v", err)
+		}
+		log.Printf("Load token registry %v completed", TokenRegistry)
+	}
+	if Token != "" {
+		if _, err := wallet.GetToken(Token); err != nil {
+			log.Fatalf("Get token error: %v", err)
+		}
+	}
+}
+
+// tokenDescriptors returns output descriptors of the path derived from root key if flag descriptors is set.
+func tokenDescriptors(root crypto.CKDKey, hdPath string) []wallet.Descriptor {
+	if !Descriptors {
+		return nil
+	}
+	network, exists := wallet.DescriptorNetworkMap[Token]
+	if !exists {
+		log.Fatalf("Descriptors of token %v not support, supported tokens: BTC, XTN", Token)
+	}
+	descriptors, err := wallet.GenerateDescriptors(root, hdPath, network)
+	if err != nil {
+		log.Fatalf("Generate descriptors error: %v", err)
+	}
+	for _, descriptor := range descriptors {
+		log.Printf("Path: %v %v descriptor: %v", hdPath, descriptor.Type, descriptor.Descriptor)
+	}
+	return descriptors
+}
+
+// checkExtendedKeyVersion checks flag extended key version is supported.
+func checkExtendedKeyVersion() {
+	if ExtendedKeyVersion == "" {
+		return
+	}
+	if _, exists := crypto.ExtendedKeyVersions[ExtendedKeyVersion]; !exists {
+		log.Fatalf("Extended key version %v not support", ExtendedKeyVersion)
+	}
+}
+
+// extendedKeyString encodes the derived ECDSA child extended key with flag extended key version, xpub or xprv by default.
+func extendedKeyString(dk crypto.CKDKey) string {
+	version, exists := crypto.ExtendedKeyVersions[ExtendedKeyVersion]
+	if !exists || dk.GetType() != crypto.ECDSAKey {
+		return dk.String()
+	}
+	extendedKey, err := crypto.B58SerializeWithVersion(dk, version)
+	if err != nil {
+		log.Fatalf("Serialize extended key error: %v", err)
+	}
+	return extendedKey
+}
+
+func tokenAddresses(tokenName string, key crypto.CKDKey) ([]wallet.Address, error) {
+	token, err := wallet.GetToken(tokenName)
+	if err != nil {
+		return nil, err
+	}
+	return token.GenerateAddresses(key)
+}
+
+// tokenWIF encodes the child private key in WIF if the token supports, otherwise returns empty string.
+func tokenWIF(tokenName string, dk crypto.CKDKey) (string, error) {
+	network, exists := wallet.WIFNetworkMap[tokenName]
+	if !exists || !dk.IsPrivateKey() {
+		return "", nil
+	}
+	return wallet.EncodeWIF(dk, network)
+}
+
+func formatAddresses(addresses []wallet.Address) string {
+	formatted := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		if address.Type == "" {
+			formatted = append(formatted, address.Address)
+		} else {
+			formatted = append(formatted, address.Type+": "+address.Address)
 		}
 	}
+	return strings.Join(formatted, "; ")
 }
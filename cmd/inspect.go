@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/cipher"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/tss"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/version"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// Output formats of inspect command.
+const (
+	InspectOutputTable = "table"
+	InspectOutputJSON  = "json"
+)
+
+var InspectOutput string
+
+var inspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "List groups and metadata of TSS recovery group files without decrypting shares",
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		// stdout is kept for the groups
+		fmt.Fprintln(os.Stderr, "Version: "+version.TextVersion()+"\n")
+		inspect()
+	},
+}
+
+// InspectGroup is the metadata of a group in a recovery group file, encrypted share is never included.
+type InspectGroup struct {
+	File                  string               `json:"file"`
+	GroupID               string               `json:"group_id"`
+	Version               int32                `json:"version"`
+	Type                  string               `json:"type"`
+	Curve                 string               `json:"curve"`
+	Threshold             int32                `json:"threshold"`
+	Participants          tss.ParticipantsInfo `json:"participants"`
+	RootExtendedPublicKey string               `json:"root_extended_public_key"`
+	CreatedTime           string               `json:"created_time"`
+	NodeID                string               `json:"node_id"`
+	ShareID               string               `json:"share_id"`
+	KDF                   *cipher.KDF          `json:"kdf"`
+}
+
+func inspect() {
+	if InspectOutput != InspectOutputTable && InspectOutput != InspectOutputJSON {
+		log.Fatalf("Output format %v not support, supported formats: table, json", InspectOutput)
+	}
+	// listed files must be recovery group files, other files in recovery dir are skipped
+	files, skipInvalid := GroupFiles, false
+	if RecoveryDir != "" {
+		files, skipInvalid = recoveryDirFiles(RecoveryDir), true
+	}
+
+	groups := make([]*InspectGroup, 0)
+	for _, groupFile := range readGroupFiles(files, skipInvalid) {
+		group := groupFile.Group
+		if group.GroupInfo == nil {
+			log.Printf("Skip group without group info in file %v", groupFile.File)
+			continue
+		}
+		inspectGroup := &InspectGroup{
+			File:                  groupFile.File,
+			GroupID:               group.GroupInfo.ID,
+			Version:               group.Version,
+			Type:                  groupTypeName(group.GroupInfo.Type),
+			Curve:                 group.GroupInfo.Curve,
+			Threshold:             group.GroupInfo.Threshold,
+			Participants:          group.GroupInfo.Participants,
+			RootExtendedPublicKey: group.GroupInfo.RootExtendedPubKey,
+			CreatedTime:           group.GroupInfo.CreatedTime,
+		}
+		if group.ShareInfo != nil {
+			inspectGroup.NodeID = group.ShareInfo.NodeID
+			inspectGroup.ShareID = group.ShareInfo.ShareID
+			inspectGroup.KDF = group.ShareInfo.KDF
+		}
+		groups = append(groups, inspectGroup)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].GroupID != groups[j].GroupID {
+			return groups[i].GroupID < groups[j].GroupID
+		}
+		return groups[i].NodeID < groups[j].NodeID
+	})
+	log.Printf("Found %v groups in %v files", len(groups), len(files))
+
+	if InspectOutput == InspectOutputJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(groups); err != nil {
+			log.Fatalf("Write groups error: %v", err)
+		}
+		return
+	}
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "GROUP ID\tVERSION\tTYPE\tCURVE\tTHRESHOLD\tNODE ID\tSHARE ID\tKDF\tCREATED TIME\tROOT EXTENDED PUBLIC KEY\tFILE")
+	for _, group := range groups {
+		fmt.Fprintf(writer, "%v\t%v\t%v\t%v\t%v/%v\t%v\t%v\t%v\t%v\t%v\t%v\n", group.GroupID, group.Version, group.Type, group.Curve,
+			group.Threshold, len(group.Participants), group.NodeID, group.ShareID, kdfDescription(group.KDF), group.CreatedTime,
+			group.RootExtendedPublicKey, group.File)
+	}
+	if err := writer.Flush(); err != nil {
+		log.Fatalf("Write groups error: %v", err)
+	}
+}
+
+func groupTypeName(groupType int32) string {
+	switch groupType {
+	case tss.GroupTypeEcdsaTSS:
+		return "ecdsa-tss"
+	case tss.GroupTypeEddsaTSS:
+		return "eddsa-tss"
+	default:
+		return strconv.Itoa(int(groupType))
+	}
+}
+
+func kdfDescription(kdf *cipher.KDF) string {
+	if kdf == nil {
+		return "none"
+	}
+	return fmt.Sprintf("pbkdf2-%v/%v/%v", kdf.HashName, kdf.Iterations, kdf.Length)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/wallet"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	keystorePassphrase string
+	// keystoreFiles contains keystore files written by address, a key in several csv lines is written once.
+	keystoreFiles = map[string]string{}
+)
+
+// readKeystorePassphrase reads and confirms the passphrase of keystore files if flag keystore-dir is set.
+func readKeystorePassphrase() {
+	if KeystoreDir == "" {
+		return
+	}
+	keystorePassphrase = newCredentials(KeystorePassphraseName, fmt.Sprintf("Enter password to encrypt keystore files in %v", KeystoreDir),
+		KeystorePassphraseSource)
+}
+
+// checkKeystoreToken requires EVM token flag for paths if flag keystore-dir is set,
+// otherwise child private keys of paths are not written in keystore files but logged in plaintext.
+func checkKeystoreToken() {
+	if KeystoreDir == "" || len(Paths) == 0 {
+		return
+	}
+	if token, err := wallet.GetToken(Token); err != nil || !token.EVM {
+		log.Fatalf("Flag keystore-dir with paths requires EVM token, token %q is not EVM token", Token)
+	}
+}
+
+// writeEVMKeystore writes the child private key of EVM token as keystore V3 file named by address,
+// returns empty file name if flag keystore-dir is not set or the token is not EVM token.
+// An unresolved token fails if flag keystore-dir is set, an EVM key of it would be written in plaintext.
+func writeEVMKeystore(tokenName string, dk crypto.CKDKey) (string, error) {
+	if KeystoreDir == "" || !dk.IsPrivateKey() {
+		return "", nil
+	}
+	token, err := wallet.GetToken(tokenName)
+	if err != nil {
+		return "", fmt.Errorf("token %q not support, set coin or EVM token flag to write keystore file", tokenName)
+	}
+	if !token.EVM {
+		log.Warnf("Token %v is not EVM token, child private key is written in plaintext instead of keystore file", tokenName)
+		return "", nil
+	}
+	addresses, err := wallet.GenerateEVMAddress(dk)
+	if err != nil {
+		return "", err
+	}
+	if file, exists := keystoreFiles[addresses[0].Address]; exists {
+		return file, nil
+	}
+	address, keyJSON, err := wallet.EncryptEVMKeystore(dk, keystorePassphrase, keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(KeystoreDir, 0o700); err != nil {
+		return "", fmt.Errorf("create keystore dir %v failed: %v", KeystoreDir, err)
+	}
+	file := filepath.Join(KeystoreDir, address.Address+".json")
+	writeFile, err := os.OpenFile(filepath.Clean(file), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", fmt.Errorf("create keystore file %v failed, please backup and remove: %v", file, err)
+	}
+	defer writeFile.Close()
+	if _, err := writeFile.Write(keyJSON); err != nil {
+		return "", fmt.Errorf("write keystore file %v failed: %v", file, err)
+	}
+	keystoreFiles[address.Address] = file
+	return file, nil
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/wallet"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/version"
+	log "github.com/sirupsen/logrus"
+)
+
+// Output formats of flag output, text only writes logs,
+// json writes an array of records and jsonl writes a record per line on stdout.
+const (
+	OutputText  = "text"
+	OutputJSON  = "json"
+	OutputJSONL = "jsonl"
+)
+
+// Record types of structured output.
+const (
+	RecordGroup = "group"
+	RecordRoot  = "root"
+	RecordKey   = "key"
+)
+
+// Record is a structured output record, fields not related to the record type are omitted.
+// A group record is a verified recovery group file, a root record is the root extended public key,
+// and a key record is a derived child key with addresses and address status if verified with the csv file.
+type Record struct {
+	Type                  string             `json:"type"`
+	GroupID               string             `json:"group_id,omitempty"`
+	File                  string             `json:"file,omitempty"`
+	NodeID                string             `json:"node_id,omitempty"`
+	Curve                 string             `json:"curve,omitempty"`
+	Threshold             int32              `json:"threshold,omitempty"`
+	RootExtendedPublicKey string             `json:"root_extended_public_key,omitempty"`
+	Path                  string             `json:"path,omitempty"`
+	ExtendedPublicKey     string             `json:"extended_public_key,omitempty"`
+	Token                 string             `json:"token,omitempty"`
+	Addresses             []RecordAddress    `json:"addresses,omitempty"`
+	Descriptors           []RecordDescriptor `json:"descriptors,omitempty"`
+	Address               string             `json:"address,omitempty"`
+	Status                string             `json:"status,omitempty"`
+	Error                 string             `json:"error,omitempty"`
+	ChildPubKeyStatus     string             `json:"child_public_key_status,omitempty"`
+	KeystoreFile          string             `json:"keystore_file,omitempty"`
+}
+
+// RecordAddress is an address of a key record, Type is empty for tokens with only one address type.
+type RecordAddress struct {
+	Type    string `json:"type"`
+	Address string `json:"address"`
+}
+
+// RecordDescriptor is a BIP-380 output descriptor of a key record, Type is the address type of the descriptor.
+type RecordDescriptor struct {
+	Type       string `json:"type"`
+	Descriptor string `json:"descriptor"`
+}
+
+var (
+	records        = make([]*Record, 0)
+	recordsFlushed bool
+)
+
+// initOutput checks flag output, and flushes collected records when the command exits by fatal logs.
+func initOutput() {
+	switch Output {
+	case OutputText, OutputJSON, OutputJSONL:
+	default:
+		log.Fatalf("Output format %v not support, supported formats: text, json, jsonl", Output)
+	}
+	log.RegisterExitHandler(flushRecords)
+}
+
+// printVersion prints version on stdout of text output, otherwise on stderr to keep stdout structured.
+func printVersion() {
+	if Output == OutputText {
+		fmt.Println("Version: " + version.TextVersion() + "\n")
+		return
+	}
+	fmt.Fprintln(os.Stderr, "Version: "+version.TextVersion()+"\n")
+}
+
+// emitRecord writes the record on stdout of jsonl output, or collects it for json output.
+func emitRecord(record *Record) {
+	switch Output {
+	case OutputJSONL:
+		data, err := json.Marshal(record)
+		if err != nil {
+			log.Fatalf("Marshal output record error: %v", err)
+		}
+		fmt.Println(string(data))
+	case OutputJSON:
+		records = append(records, record)
+	}
+}
+
+// flushRecords writes collected records of json output on stdout once.
+func flushRecords() {
+	if Output != OutputJSON || recordsFlushed {
+		return
+	}
+	recordsFlushed = true
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(records); err != nil {
+		log.Errorf("Write output records error: %v", err)
+	}
+}
+
+func recordAddresses(addresses []wallet.Address) []RecordAddress {
+	recordAddresses := make([]RecordAddress, 0, len(addresses))
+	for _, address := range addresses {
+		recordAddresses = append(recordAddresses, RecordAddress{Type: address.Type, Address: address.Address})
+	}
+	return recordAddresses
+}
+
+func recordDescriptors(descriptors []wallet.Descriptor) []RecordDescriptor {
+	if len(descriptors) == 0 {
+		return nil
+	}
+	recordDescriptors := make([]RecordDescriptor, 0, len(descriptors))
+	for _, descriptor := range descriptors {
+		recordDescriptors = append(recordDescriptors, RecordDescriptor{Type: descriptor.Type, Descriptor: descriptor.Descriptor})
+	}
+	return recordDescriptors
+}
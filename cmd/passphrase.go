@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/cipher"
+	log "github.com/sirupsen/logrus"
+)
+
+const passphraseSourceUsage = "passphrase source of recovery group files (default tty), repeatable, " +
+	"<source> for all files or <group file>=<source> for a file, sources: tty, env:<name>, file:<path>, fd:<number>, json:<path>"
+
+// Names of passphrases other than recovery group files, which are the keys of json passphrase source.
+const (
+	KeystorePassphraseName = "keystore"
+	OutputPassphraseName   = "csv-output"
+)
+
+var (
+	PassphraseSources        []string
+	KeystorePassphraseSource string
+	OutputPassphraseSource   string
+
+	// defaultPassphraseProvider and groupFilePassphraseProviders are parsed from flag passphrase-source.
+	defaultPassphraseProvider    cipher.PassphraseProvider
+	groupFilePassphraseProviders map[string]cipher.PassphraseProvider
+	// passphraseProviders are providers by source, shared by passphrases of the same source
+	passphraseProviders = map[string]cipher.PassphraseProvider{}
+)
+
+// initPassphraseProviders parses flag passphrase-source, the later source overrides the former one of the same files,
+// sources of keystore and csv output passphrases are also parsed before any passphrase is read.
+func initPassphraseProviders() {
+	defaultPassphraseProvider = passphraseProvider(cipher.PassphraseSourceTTY)
+	groupFilePassphraseProviders = make(map[string]cipher.PassphraseProvider)
+	for _, passphraseSource := range PassphraseSources {
+		groupFile, source := "", passphraseSource
+		if !isPassphraseSource(passphraseSource) {
+			var found bool
+			groupFile, source, found = strings.Cut(passphraseSource, "=")
+			if !found || groupFile == "" {
+				log.Fatalf("Passphrase source %v is invalid, should be <source> or <group file>=<source>", passphraseSource)
+			}
+		}
+		if groupFile == "" {
+			defaultPassphraseProvider = passphraseProvider(source)
+		} else {
+			groupFilePassphraseProviders[groupFile] = passphraseProvider(source)
+		}
+	}
+	passphraseProvider(KeystorePassphraseSource)
+	passphraseProvider(OutputPassphraseSource)
+}
+
+// passphraseProvider returns the provider of source (default tty), fd source shared by passphrases reads successive lines.
+func passphraseProvider(source string) cipher.PassphraseProvider {
+	if source == "" {
+		source = cipher.PassphraseSourceTTY
+	}
+	if provider, exists := passphraseProviders[source]; exists {
+		return provider
+	}
+	provider, err := cipher.ParsePassphraseSource(source)
+	if err != nil {
+		log.Fatalln("Passphrase source error:", err)
+	}
+	passphraseProviders[source] = provider
+	return provider
+}
+
+func isPassphraseSource(source string) bool {
+	if source == cipher.PassphraseSourceTTY {
+		return true
+	}
+	for _, kind := range []string{cipher.PassphraseSourceEnv, cipher.PassphraseSourceFile, cipher.PassphraseSourceFD, cipher.PassphraseSourceJSON} {
+		if strings.HasPrefix(source, kind+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// groupFilePassphrase returns the passphrase to decrypt share secret of group file from its passphrase source.
+func groupFilePassphrase(groupFile string) string {
+	provider := defaultPassphraseProvider
+	// group file is matched by the path, then by the base name, same as json source
+	for _, name := range []string{filepath.Base(groupFile), filepath.Clean(groupFile), groupFile} {
+		if groupFileProvider, ok := groupFilePassphraseProviders[name]; ok {
+			provider = groupFileProvider
+		}
+	}
+	return readPassphrase(provider, groupFile, fmt.Sprintf("Enter password to decrypt share secret from %v", groupFile))
+}
+
+// readPassphrase reads the passphrase of name from provider, the message is shown before typing in terminal,
+// and a warning is logged for non-interactive provider.
+func readPassphrase(provider cipher.PassphraseProvider, name string, message string) string {
+	if provider.Interactive() {
+		fmt.Fprintln(os.Stderr, message)
+	} else {
+		log.Warnf("!!! WARNING: password of %v is read from non-interactive source %v, "+
+			"make sure it is removed from environment, disk and shell history after recovery !!!", name, provider)
+	}
+	passphrase, err := provider.Passphrase(name, "Password:")
+	if err != nil {
+		log.Fatalln("Credentials error:", err)
+	}
+	return passphrase
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
 	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/tss"
 	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/utils"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/wallet"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -25,15 +27,73 @@ type Wallet struct {
 	Version     uint32
 	AddressInfo *AddressInfo
 }
+
+// Address status column values of the recovery csv file.
+const (
+	AddressMatch       = "match"
+	AddressMismatch    = "mismatch"
+	AddressUnsupported = "unsupported"
+	AddressMissing     = "no-address"
+	AddressError       = "error"
+)
+
+// Child public key status column values of the recovery csv file.
+const (
+	ChildPubKeyMatch    = "match"
+	ChildPubKeyMismatch = "mismatch"
+	ChildPubKeyMissing  = "no-child-public-key"
+)
+
 type AddressInfo struct {
 	Name        string
+	Coin        string
+	Address     string
 	Curve       string
 	HDPath      string
 	ChildPubKey string
 }
 
-//nolint:gocognit
 func recovery() {
+	loadTokenRegistry()
+	checkExtendedKeyVersion()
+	checkKeystoreToken()
+	key := reconstructRootKey()
+	emitRecord(&Record{Type: RecordRoot, GroupID: GroupID, RootExtendedPublicKey: extendedKeyString(key.PublicKey())})
+	readKeystorePassphrase()
+	if EncryptOutput && Csv != "" {
+		outputPassphrase = newCredentials(OutputPassphraseName, "Enter password to encrypt csv output file", OutputPassphraseSource)
+	}
+	if err := DeriveKey(key); err != nil {
+		log.Fatalf("Failed to derive key: %v", err)
+	}
+	if ShowRootPrivate {
+		log.Println("Reconstructed root private key:", utils.Encode(key.GetKey()))
+		log.Println("Reconstructed root extended private key:", extendedKeyString(key))
+	}
+	log.Println("Reconstructed root extended public key:", extendedKeyString(key.PublicKey()))
+}
+
+// rootPrivateKey returns root extended private key from flag key, or reconstructs it from recovery group files.
+func rootPrivateKey() crypto.CKDKey {
+	if RootKey == "" {
+		return reconstructRootKey()
+	}
+	key, err := crypto.B58Deserialize(RootKey)
+	if err != nil {
+		log.Fatalf("Failed to deserialize root key: %v", err)
+	}
+	if !key.IsPrivateKey() {
+		log.Fatal("Root key is not extended private key")
+	}
+	return key
+}
+
+// reconstructRootKey reconstructs root private key from shares decrypted from recovery group files.
+//
+//nolint:gocognit
+func reconstructRootKey() crypto.CKDKey {
+	discoverGroupFiles()
+	initPassphraseProviders()
 	if len(GroupFiles) == 0 {
 		log.Fatal("no recovery group files")
 	}
@@ -54,18 +114,8 @@ func recovery() {
 			log.Fatalf("Read recovery group file %v failed: %v", groupFile, err)
 		}
 
-		var groups []*tss.Group
-		rSecrets := tss.RecoverySecrets{RecoveryGroups: make([]*tss.Group, 0)}
-		rGroups := make([]*tss.Group, 0)
-		var rGroup tss.Group
-		if err := json.Unmarshal(groupBytes, &rSecrets); err == nil && len(rSecrets.RecoveryGroups) > 0 {
-			groups = rSecrets.RecoveryGroups
-		} else if err := json.Unmarshal(groupBytes, &rGroups); err == nil && len(rGroups) > 0 {
-			groups = rGroups
-		} else if err := json.Unmarshal(groupBytes, &rGroup); err == nil {
-			rGroups = append(rGroups, &rGroup)
-			groups = rGroups
-		} else {
+		groups, err := tss.ParseRecoveryGroups(groupBytes)
+		if err != nil {
 			log.Fatalf("Cannot parse recovery group file: %v", groupFile)
 		}
 
@@ -93,11 +143,7 @@ func recovery() {
 		}
 		recoveryGroups = append(recoveryGroups, group)
 
-		fmt.Printf("Enter password to decrypt share secret from %v\n", groupFile)
-		key, err := cipher.Credentials("Password:")
-		if err != nil {
-			log.Fatalln("Credentials error:", err)
-		}
+		key := groupFilePassphrase(groupFile)
 		share, err := group.DecryptShare(key)
 		if err != nil {
 			log.Fatalln("Group generate share error:", err)
@@ -116,14 +162,29 @@ func recovery() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	if err := DeriveKey(key); err != nil {
-		log.Fatalf("Failed to derive key: %v", err)
+	return key
+}
+
+// newCredentials reads a new password of name from the source, the password is typed twice in terminal
+// after the message and checked they are the same if the source is tty.
+func newCredentials(name string, message string, source string) string {
+	provider := passphraseProvider(source)
+	if !provider.Interactive() {
+		return readPassphrase(provider, name, message)
 	}
-	if ShowRootPrivate {
-		log.Println("Reconstructed root private key:", utils.Encode(key.GetKey()))
-		log.Println("Reconstructed root extended private key:", key.String())
+	fmt.Fprintln(os.Stderr, message)
+	passphrase, err := cipher.Credentials("Password:")
+	if err != nil {
+		log.Fatalln("Credentials error:", err)
 	}
-	log.Println("Reconstructed root extended public key:", key.PublicKey().String())
+	confirm, err := cipher.Credentials("Confirm password:")
+	if err != nil {
+		log.Fatalln("Credentials error:", err)
+	}
+	if passphrase != confirm {
+		log.Fatal("Passwords mismatch")
+	}
+	return passphrase
 }
 
 func DeriveKey(key crypto.CKDKey) error {
@@ -136,11 +197,38 @@ func DeriveKey(key crypto.CKDKey) error {
 			if err != nil {
 				log.Fatalf("Derive path %v error: %v", hdPath, err)
 			}
-			if dk.IsPrivateKey() {
+			keystoreFile, err := writeEVMKeystore(Token, dk)
+			if err != nil {
+				log.Fatalf("Write keystore error: %v", err)
+			}
+			if keystoreFile != "" {
+				log.Printf("Path: %v derived child %v keystore file: %v", hdPath, Token, keystoreFile)
+			} else if dk.IsPrivateKey() {
 				log.Printf("Path: %v derived child private key: %v", hdPath, utils.Encode(dk.GetKey()))
-				log.Printf("Path: %v derived child extended private key: %v", hdPath, dk.String())
+				log.Printf("Path: %v derived child extended private key: %v", hdPath, extendedKeyString(dk))
+			}
+			log.Printf("Path: %v derived child extended public key: %v", hdPath, extendedKeyString(dk.PublicKey()))
+			record := &Record{Type: RecordKey, Path: hdPath, ExtendedPublicKey: extendedKeyString(dk.PublicKey()), Token: Token, KeystoreFile: keystoreFile}
+
+			if Token != "" {
+				addresses, err := tokenAddresses(Token, dk)
+				if err != nil {
+					log.Fatalf("Generate address error: %v", err)
+				}
+				for _, address := range addresses {
+					log.Printf("Token %v Address Type: %v, Address: %v", Token, address.Type, address.Address)
+				}
+				wif, err := tokenWIF(Token, dk)
+				if err != nil {
+					log.Fatalf("Encode WIF error: %v", err)
+				}
+				if wif != "" {
+					log.Printf("Path: %v derived child %v WIF private key: %v", hdPath, Token, wif)
+				}
+				record.Addresses = recordAddresses(addresses)
 			}
-			log.Printf("Path: %v derived child extended public key: %v", hdPath, dk.PublicKey().String())
+			record.Descriptors = recordDescriptors(tokenDescriptors(key, hdPath))
+			emitRecord(record)
 		}
 		return nil
 	}
@@ -156,6 +244,9 @@ func DeriveKey(key crypto.CKDKey) error {
 	fileName := strings.TrimSuffix(fileFullName, fileType)
 	CsvOutputFile := strings.TrimSuffix(CsvOutputDir, "/") + "/" + fileName + "-recovery-" +
 		time.Now().Format(time.RFC3339) + fileType
+	if outputPassphrase != "" {
+		CsvOutputFile += EncryptedFileExt
+	}
 	if _, err := os.Stat(CsvOutputFile); err == nil || os.IsExist(err) {
 		log.Fatalf("File %v already exists, please backup and remove", CsvOutputFile)
 	}
@@ -167,7 +258,8 @@ func DeriveKey(key crypto.CKDKey) error {
 	return nil
 }
 
-//nolint:gocognit
+// CSVFileDerive derives keys of addresses in the input csv file and writes them to the output file,
+// the output file is an encrypted envelope if the csv output password is set.
 func CSVFileDerive(key crypto.CKDKey, inputFile string, outputFile string) error {
 	readFile, err := os.Open(filepath.Clean(inputFile))
 	if err != nil {
@@ -181,27 +273,46 @@ func CSVFileDerive(key crypto.CKDKey, inputFile string, outputFile string) error
 	defer readFile.Close()
 	defer writeFile.Close()
 
-	reader := csv.NewReader(readFile)
-	writer := csv.NewWriter(writeFile)
+	if outputPassphrase == "" {
+		return csvDerive(key, readFile, writeFile, inputFile, outputFile)
+	}
+	// derived keys are written in memory and sealed, plaintext never touches disk
+	var plaintext bytes.Buffer
+	deriveErr := csvDerive(key, readFile, &plaintext, inputFile, outputFile)
+	envelope, err := cipher.SealEnvelope(outputPassphrase, plaintext.Bytes())
+	if err != nil {
+		return fmt.Errorf("encrypt %v failed: %v", outputFile, err)
+	}
+	if err := json.NewEncoder(writeFile).Encode(envelope); err != nil {
+		return fmt.Errorf("write %v failed: %v", outputFile, err)
+	}
+	return deriveErr
+}
+
+//nolint:gocognit
+func csvDerive(key crypto.CKDKey, input io.Reader, output io.Writer, inputFile string, outputFile string) error {
+	reader := csv.NewReader(input)
+	writer := csv.NewWriter(output)
 
 	// title line
 	line, err := reader.Read()
 	if err != nil && err != io.EOF {
 		return fmt.Errorf("read error: %v", err)
 	}
-	wallet := Wallet{}
+	csvWallet := Wallet{}
 	if line[0] != "wallet name" {
 		return fmt.Errorf("first line is not title in csv file")
 	}
 	if len(line) == 7 {
-		wallet.Version = 0
+		csvWallet.Version = 0
 	} else if len(line) == 8 && line[3] == "curve" {
-		wallet.Version = 1
+		csvWallet.Version = 1
 	} else {
 		return fmt.Errorf("title line not recognized")
 	}
 
-	writeTitle := append(line, "hex private key", "extended private key", "extended public key")
+	writeTitle := append(line, "hex private key", "extended private key", "extended public key",
+		"derived address", "matched address type", "address status", "child public key status", "wif private key", "keystore file")
 	err = writer.Write(writeTitle)
 	if err != nil {
 		return fmt.Errorf("write title error: %v", err)
@@ -209,6 +320,7 @@ func CSVFileDerive(key crypto.CKDKey, inputFile string, outputFile string) error
 	writer.Flush()
 
 	// handle each line
+	mismatches, childPubKeyMismatches, unverified := 0, 0, 0
 	for {
 		line, err := reader.Read()
 		if err == io.EOF {
@@ -216,17 +328,21 @@ func CSVFileDerive(key crypto.CKDKey, inputFile string, outputFile string) error
 		} else if err != nil {
 			return fmt.Errorf("read error: %v", err)
 		}
-		switch wallet.Version {
+		switch csvWallet.Version {
 		case 0:
-			wallet.AddressInfo = &AddressInfo{
+			csvWallet.AddressInfo = &AddressInfo{
 				Name:        line[0],
+				Coin:        line[1],
+				Address:     line[2],
 				Curve:       "secp256k1",
 				HDPath:      line[5],
 				ChildPubKey: line[6],
 			}
 		case 1:
-			wallet.AddressInfo = &AddressInfo{
+			csvWallet.AddressInfo = &AddressInfo{
 				Name:        line[0],
+				Coin:        line[1],
+				Address:     line[2],
 				Curve:       line[3],
 				HDPath:      line[6],
 				ChildPubKey: line[7],
@@ -235,35 +351,132 @@ func CSVFileDerive(key crypto.CKDKey, inputFile string, outputFile string) error
 			return fmt.Errorf("error wallet version")
 		}
 
-		if key.GetType() == crypto.ECDSAKey && crypto.CurveNameType[wallet.AddressInfo.Curve] != crypto.SECP256K1 {
+		if key.GetType() == crypto.ECDSAKey && crypto.CurveNameType[csvWallet.AddressInfo.Curve] != crypto.SECP256K1 {
 			continue
 		}
-		if key.GetType() == crypto.EDDSAKey && crypto.CurveNameType[wallet.AddressInfo.Curve] != crypto.ED25519 {
+		if key.GetType() == crypto.EDDSAKey && crypto.CurveNameType[csvWallet.AddressInfo.Curve] != crypto.ED25519 {
 			continue
 		}
 
-		dk, err := crypto.Derive(key, wallet.AddressInfo.HDPath)
+		dk, err := crypto.Derive(key, csvWallet.AddressInfo.HDPath)
 		if err != nil {
-			return fmt.Errorf("address %v derive error: %v", wallet.AddressInfo, err)
+			return fmt.Errorf("address %v derive error: %v", csvWallet.AddressInfo, err)
+		}
+		// token flag is only for rows without coin, mixed coin rows keep their own tokens
+		tokenName := strings.TrimSpace(csvWallet.AddressInfo.Coin)
+		if tokenName == "" {
+			tokenName = Token
+		}
+		// private key of keystore file is not written in plaintext
+		keystoreFile, err := writeEVMKeystore(tokenName, dk)
+		if err != nil {
+			return fmt.Errorf("address %v write keystore error: %v", csvWallet.AddressInfo, err)
+		}
+		hexPrivateKey, extendedPrivateKey := "", ""
+		if keystoreFile != "" {
+			log.Printf("Path: %v derived child %v keystore file: %v", csvWallet.AddressInfo.HDPath, tokenName, keystoreFile)
+		} else if dk.IsPrivateKey() {
+			hexPrivateKey, extendedPrivateKey = utils.Encode(dk.GetKey()), extendedKeyString(dk)
+			// private keys of encrypted output are only in the envelope, not in logs
+			if outputPassphrase == "" {
+				log.Printf("Path: %v derived child private key: %v", csvWallet.AddressInfo.HDPath, hexPrivateKey)
+				log.Printf("Path: %v derived child extended private key: %v", csvWallet.AddressInfo.HDPath, extendedPrivateKey)
+			}
+		}
+		log.Printf("Path: %v derived child extended public key: %v", csvWallet.AddressInfo.HDPath, extendedKeyString(dk.PublicKey()))
+
+		childPubKeyStatus := verifyChildPubKey(dk, csvWallet.AddressInfo.ChildPubKey)
+		if childPubKeyStatus == ChildPubKeyMismatch {
+			childPubKeyMismatches++
+			log.Errorf("Derived child public key mismatch, address info: %v", csvWallet.AddressInfo)
 		}
-		if dk.IsPrivateKey() {
-			log.Printf("Path: %v derived child private key: %v", wallet.AddressInfo.HDPath, utils.Encode(dk.GetKey()))
-			log.Printf("Path: %v derived child extended private key: %v", wallet.AddressInfo.HDPath, dk.String())
+
+		status, addresses, matched, err := verifyAddress(tokenName, dk, csvWallet.AddressInfo.Address)
+		statusColumn, statusError := status, ""
+		switch status {
+		case AddressMatch:
+			log.Printf("Address %v matches derived %v address type: %v", csvWallet.AddressInfo.Address, tokenName, matched.Type)
+		case AddressMismatch:
+			mismatches++
+			log.Errorf("Derived %v address mismatch, address info: %v", tokenName, csvWallet.AddressInfo)
+		case AddressMissing:
+			unverified++
+			log.Warnf("Address is empty, not verified, address info: %v", csvWallet.AddressInfo)
+		case AddressError:
+			unverified++
+			statusError = err.Error()
+			statusColumn = AddressError + ": " + statusError
+			log.Errorf("Generate %v address error: %v, address info: %v", tokenName, err, csvWallet.AddressInfo)
+		default:
+			unverified++
+			log.Warnf("Token %v address unsupported, address info: %v", tokenName, csvWallet.AddressInfo)
 		}
-		log.Printf("Path: %v derived child extended public key: %v", wallet.AddressInfo.HDPath, dk.PublicKey().String())
 
-		childPubKey := strings.TrimSpace(strings.ReplaceAll(wallet.AddressInfo.ChildPubKey, " ", ""))
-		if childPubKey != "" && dk.PublicKey().String() != "" && childPubKey != dk.PublicKey().String() {
-			log.Warnf("Derived child public key mismatch, address info: %v", wallet.AddressInfo)
+		wif, err := tokenWIF(tokenName, dk)
+		if err != nil {
+			return fmt.Errorf("address %v encode WIF error: %v", csvWallet.AddressInfo, err)
 		}
 
 		// write to csv file
-		writeLine := append(line, utils.Encode(dk.GetKey()), dk.String(), dk.PublicKey().String())
+		writeLine := append(line, hexPrivateKey, extendedPrivateKey, extendedKeyString(dk.PublicKey()),
+			formatAddresses(addresses), matched.Type, statusColumn, childPubKeyStatus, wif, keystoreFile)
 		if err := writer.Write(writeLine); err != nil {
 			return fmt.Errorf("write derived keys error: %v", err)
 		}
 		writer.Flush()
+		emitRecord(&Record{
+			Type:              RecordKey,
+			Path:              csvWallet.AddressInfo.HDPath,
+			ExtendedPublicKey: extendedKeyString(dk.PublicKey()),
+			Token:             tokenName,
+			Addresses:         recordAddresses(addresses),
+			Address:           csvWallet.AddressInfo.Address,
+			Status:            status,
+			Error:             statusError,
+			ChildPubKeyStatus: childPubKeyStatus,
+			KeystoreFile:      keystoreFile,
+		})
 	}
 	log.Printf("Derive keys from %s to %s completed", inputFile, outputFile)
+	if unverified > 0 {
+		log.Warnf("%v addresses not verified, unsupported, empty or error, see address status in %v", unverified, outputFile)
+	}
+	if mismatches > 0 || childPubKeyMismatches > 0 {
+		return fmt.Errorf("%v addresses and %v child public keys mismatch, see address status and child public key status in %v",
+			mismatches, childPubKeyMismatches, outputFile)
+	}
 	return nil
 }
+
+// verifyChildPubKey compares the derived child public key with the exported child public key.
+func verifyChildPubKey(dk crypto.CKDKey, childPubKey string) string {
+	childPubKey = strings.TrimSpace(strings.ReplaceAll(childPubKey, " ", ""))
+	if childPubKey == "" {
+		return ChildPubKeyMissing
+	}
+	if childPubKey != dk.PublicKey().String() {
+		return ChildPubKeyMismatch
+	}
+	return ChildPubKeyMatch
+}
+
+// verifyAddress recomputes addresses of the token from the derived key and compares with the exported address,
+// the error is only returned with AddressError status.
+func verifyAddress(tokenName string, dk crypto.CKDKey, address string) (string, []wallet.Address, wallet.Address, error) {
+	token, err := wallet.GetToken(tokenName)
+	if err != nil {
+		return AddressUnsupported, nil, wallet.Address{}, nil
+	}
+	addresses, err := token.GenerateAddresses(dk)
+	if err != nil {
+		return AddressError, nil, wallet.Address{}, err
+	}
+	if strings.TrimSpace(address) == "" {
+		return AddressMissing, addresses, wallet.Address{}, nil
+	}
+	matched, found := wallet.MatchAddress(addresses, address)
+	if !found {
+		return AddressMismatch, addresses, wallet.Address{}, nil
+	}
+	return AddressMatch, addresses, matched, nil
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/tss"
+	log "github.com/sirupsen/logrus"
+)
+
+// discoverGroupFiles scans recovery dir and sets recovery group files of the group, a file of each node is used,
+// duplicate and stale files of the same node are reported and ignored.
+func discoverGroupFiles() {
+	if RecoveryDir == "" {
+		return
+	}
+	if GroupID == "" {
+		log.Fatal("nil group ID")
+	}
+	groupFiles := readGroupFiles(recoveryDirFiles(RecoveryDir), true)
+
+	groups := tss.GroupNodeFiles(groupFiles)
+	groupIDs := make([]string, 0, len(groups))
+	for groupID := range groups {
+		groupIDs = append(groupIDs, groupID)
+	}
+	sort.Strings(groupIDs)
+	for _, groupID := range groupIDs {
+		log.Printf("Found group %v of %v nodes in recovery dir %v", groupID, len(groups[groupID]), RecoveryDir)
+	}
+	nodes, exists := groups[GroupID]
+	if !exists {
+		log.Fatalf("Not found group %v in recovery dir %v", GroupID, RecoveryDir)
+	}
+
+	GroupFiles = make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		log.Printf("Node %v recovery group file: %v", node.NodeID, node.Selected.File)
+		for _, duplicate := range node.Duplicates {
+			log.Warnf("Node %v recovery group file %v is duplicate of %v, ignored", node.NodeID, duplicate.File, node.Selected.File)
+		}
+		for _, stale := range node.Stale {
+			log.Warnf("Node %v recovery group file %v is stale, share differs from the latest file %v, ignored",
+				node.NodeID, stale.File, node.Selected.File)
+		}
+		GroupFiles = append(GroupFiles, node.Selected.File)
+	}
+}
+
+// recoveryDirFiles returns regular files in the recovery dir.
+func recoveryDirFiles(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		log.Fatalf("Read recovery dir %v failed: %v", dir, err)
+	}
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return files
+}
+
+// readGroupFiles parses groups of recovery group files, files not in recovery group format are skipped
+// if skipInvalid is set, such as files discovered in recovery dir, otherwise fatal.
+func readGroupFiles(files []string, skipInvalid bool) []*tss.GroupFile {
+	groupFiles := make([]*tss.GroupFile, 0)
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			log.Fatalf("Recovery group file %v error: %v", file, err)
+		}
+		groupBytes, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			log.Fatalf("Read recovery group file %v failed: %v", file, err)
+		}
+		groups, err := tss.ParseRecoveryGroups(groupBytes)
+		if err != nil && !skipInvalid {
+			log.Fatalf("Parse recovery group file %v failed: %v", file, err)
+		} else if err != nil {
+			log.Printf("Skip file %v, not recovery group file", file)
+			continue
+		}
+		for _, group := range groups {
+			groupFiles = append(groupFiles, &tss.GroupFile{File: file, ModTime: info.ModTime(), Group: group})
+		}
+	}
+	return groupFiles
+}
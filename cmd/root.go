@@ -4,35 +4,49 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/CoboGlobal/cobo-mpc-recovery-kits/version"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
 var (
-	GroupFiles      []string
-	GroupID         string
-	ShowRootPrivate bool
-	Paths           []string
-	Csv             string
-	CsvOutputDir    string
-	RootKey         string
-	Token           string
+	GroupFiles         []string
+	GroupID            string
+	ShowRootPrivate    bool
+	Paths              []string
+	Csv                string
+	CsvOutputDir       string
+	RootKey            string
+	Token              string
+	TokenRegistry      string
+	RecoveryDir        string
+	KeystoreDir        string
+	Output             string
+	EncryptOutput      bool
+	Descriptors        bool
+	ExtendedKeyVersion string
 )
 
+const extendedKeyVersionUsage = "version of derived child extended keys (default xpub), " +
+	"supported versions: xpub, ypub, zpub, Ypub, Zpub, tpub, upub, vpub, Upub, Vpub"
+
 func InitCmd() {
 	rootCmd.AddCommand(verifyCmd)
 	rootCmd.AddCommand(deriveCmd)
+	rootCmd.AddCommand(signPSBTCmd)
+	rootCmd.AddCommand(signEVMTxCmd)
+	rootCmd.AddCommand(signMessageCmd)
+	rootCmd.AddCommand(sweepBTCCmd)
+	rootCmd.AddCommand(signSolanaTxCmd)
+	rootCmd.AddCommand(verifySignatureCmd)
+	rootCmd.AddCommand(decryptOutputCmd)
+	rootCmd.AddCommand(inspectCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 }
 
 func AddFlag() {
-	rootCmd.Flags().StringSliceVar(&GroupFiles, "recovery-group-files", []string{},
-		"TSS recovery group files, such as recovery/recovery-secrets-node1-time1,recovery/recovery-secrets-node2-time2")
-	if err := rootCmd.MarkFlagRequired("recovery-group-files"); err != nil {
-		log.Fatal(err)
-	}
+	addGroupFilesFlags(rootCmd)
+	rootCmd.Flags().StringArrayVar(&PassphraseSources, "passphrase-source", []string{}, passphraseSourceUsage)
 	rootCmd.Flags().StringVar(&GroupID, "group-id", "", "recovery group id")
 	if err := rootCmd.MarkFlagRequired("group-id"); err != nil {
 		log.Fatal(err)
@@ -43,16 +57,112 @@ func AddFlag() {
 		"address csv file, contains HD derivation paths")
 	rootCmd.Flags().StringVar(&CsvOutputDir, "csv-output-dir", "recovery",
 		"address csv output dir, derive keys file output in this directory")
+	rootCmd.Flags().StringVar(&Token, "token", "",
+		"token, generate addresses of derived child keys (and WIF for BTC, XTN, LTC, DOGE), such as BTC, ETH, SOL")
+	rootCmd.Flags().StringVar(&TokenRegistry, "token-registry", "", "token registry file (JSON or YAML), defines additional tokens")
+	rootCmd.Flags().StringVar(&KeystoreDir, "keystore-dir", "",
+		"keystore output dir, derived child private keys of EVM tokens are written as keystore V3 files instead of plaintext")
+	rootCmd.Flags().StringVar(&Output, "output", OutputText, "output format on stdout, supported formats: text, json, jsonl")
+	rootCmd.Flags().BoolVar(&Descriptors, "descriptors", false,
+		"generate pkh, sh(wpkh), wpkh and tr output descriptors of paths, supported tokens: BTC, XTN")
+	rootCmd.Flags().StringVar(&ExtendedKeyVersion, "extended-key-version", "", extendedKeyVersionUsage)
+	rootCmd.Flags().BoolVar(&EncryptOutput, "encrypt-output", false,
+		"encrypt csv output file with password, decrypt it by decrypt-output command")
+	rootCmd.Flags().StringVar(&KeystorePassphraseSource, "keystore-passphrase-source", "",
+		"passphrase source of keystore files (default tty), see passphrase-source, json source key is "+KeystorePassphraseName)
+	rootCmd.Flags().StringVar(&OutputPassphraseSource, "output-passphrase-source", "",
+		"passphrase source of encrypted csv output file (default tty), see passphrase-source, json source key is "+OutputPassphraseName)
 
-	verifyCmd.Flags().StringSliceVar(&GroupFiles, "recovery-group-files", []string{},
-		"TSS recovery group files, such as recovery/recovery-secrets-node1-time1,recovery/recovery-secrets-node2-time2")
-	if err := verifyCmd.MarkFlagRequired("recovery-group-files"); err != nil {
-		log.Fatal(err)
-	}
+	addGroupFilesFlags(verifyCmd)
+	verifyCmd.Flags().StringArrayVar(&PassphraseSources, "passphrase-source", []string{}, passphraseSourceUsage)
 	verifyCmd.Flags().StringVar(&GroupID, "group-id", "", "recovery group id")
 	if err := verifyCmd.MarkFlagRequired("group-id"); err != nil {
 		log.Fatal(err)
 	}
+	verifyCmd.Flags().StringVar(&Output, "output", OutputText, "output format on stdout, supported formats: text, json, jsonl")
+
+	addRootPrivateKeyFlags(signPSBTCmd)
+	signPSBTCmd.Flags().StringVar(&PSBTFile, "psbt", "", "BIP174 PSBT file to sign, base64 or binary")
+	if err := signPSBTCmd.MarkFlagRequired("psbt"); err != nil {
+		log.Fatal(err)
+	}
+	signPSBTCmd.Flags().StringVar(&PSBTOutputFile, "output", "", "signed PSBT output file, base64")
+	if err := signPSBTCmd.MarkFlagRequired("output"); err != nil {
+		log.Fatal(err)
+	}
+
+	addRootPrivateKeyFlags(signEVMTxCmd)
+	signEVMTxCmd.Flags().StringVar(&TxFile, "tx", "", "EVM transaction JSON file to sign")
+	if err := signEVMTxCmd.MarkFlagRequired("tx"); err != nil {
+		log.Fatal(err)
+	}
+	signEVMTxCmd.Flags().StringVar(&HDPath, "path", "", "key HD derivation path, such as m/44/60/0/0/0")
+	if err := signEVMTxCmd.MarkFlagRequired("path"); err != nil {
+		log.Fatal(err)
+	}
+
+	addRootPrivateKeyFlags(signMessageCmd)
+	signMessageCmd.Flags().StringVar(&HDPath, "path", "", "key HD derivation path, such as m/44/60/0/0/0")
+	if err := signMessageCmd.MarkFlagRequired("path"); err != nil {
+		log.Fatal(err)
+	}
+	signMessageCmd.Flags().StringVar(&Curve, "curve", "", "key curve, supported curves: secp256k1, ed25519")
+	if err := signMessageCmd.MarkFlagRequired("curve"); err != nil {
+		log.Fatal(err)
+	}
+	signMessageCmd.Flags().StringVar(&Scheme, "scheme", "", "signature scheme of secp256k1 curve, supported schemes: eip191, eip712, bip137")
+	signMessageCmd.Flags().StringVar(&Token, "token", "",
+		"token, network of bip137 scheme (default BTC), or show signing address of ed25519 curve, such as SOL")
+	signMessageCmd.Flags().StringVar(&TokenRegistry, "token-registry", "", "token registry file (JSON or YAML), defines additional tokens")
+	signMessageCmd.Flags().StringVar(&AddressType, "address-type", "legacy",
+		"address type of bip137 scheme, supported types: legacy, nested-segwit, native-segwit")
+	addMessageFlags(signMessageCmd)
+
+	verifySignatureCmd.Flags().StringVar(&Curve, "curve", "", "key curve, supported curves: ed25519")
+	if err := verifySignatureCmd.MarkFlagRequired("curve"); err != nil {
+		log.Fatal(err)
+	}
+	verifySignatureCmd.Flags().StringVar(&PublicKey, "public-key", "", "public key in hex")
+	if err := verifySignatureCmd.MarkFlagRequired("public-key"); err != nil {
+		log.Fatal(err)
+	}
+	verifySignatureCmd.Flags().StringVar(&Signature, "signature", "", "signature in hex")
+	if err := verifySignatureCmd.MarkFlagRequired("signature"); err != nil {
+		log.Fatal(err)
+	}
+	addMessageFlags(verifySignatureCmd)
+
+	addRootPrivateKeyFlags(sweepBTCCmd)
+	sweepBTCCmd.Flags().StringVar(&UTXOFile, "utxos", "", "UTXO list JSON file, each UTXO contains txid, vout, amount, path and script_type")
+	if err := sweepBTCCmd.MarkFlagRequired("utxos"); err != nil {
+		log.Fatal(err)
+	}
+	sweepBTCCmd.Flags().StringVar(&Destination, "destination", "", "destination address")
+	if err := sweepBTCCmd.MarkFlagRequired("destination"); err != nil {
+		log.Fatal(err)
+	}
+	sweepBTCCmd.Flags().Int64Var(&FeeRate, "fee-rate", 0, "fee rate in satoshi per virtual byte")
+	if err := sweepBTCCmd.MarkFlagRequired("fee-rate"); err != nil {
+		log.Fatal(err)
+	}
+	sweepBTCCmd.Flags().StringVar(&Token, "token", "", "token, supported tokens: BTC (default), XTN")
+
+	addRootPrivateKeyFlags(signSolanaTxCmd)
+	signSolanaTxCmd.Flags().StringVar(&TxFile, "tx", "", "Solana transfer JSON file to sign")
+	if err := signSolanaTxCmd.MarkFlagRequired("tx"); err != nil {
+		log.Fatal(err)
+	}
+
+	addGroupFilesFlags(inspectCmd)
+	inspectCmd.Flags().StringVar(&InspectOutput, "output", InspectOutputTable, "output format on stdout, supported formats: table, json")
+
+	decryptOutputCmd.Flags().StringVar(&EncryptedFile, "file", "", "encrypted csv output file")
+	decryptOutputCmd.Flags().StringVar(&OutputPassphraseSource, "output-passphrase-source", "",
+		"passphrase source of encrypted csv output file (default tty), sources: tty, env:<name>, file:<path>, fd:<number>, "+
+			"json:<path> (key "+OutputPassphraseName+")")
+	if err := decryptOutputCmd.MarkFlagRequired("file"); err != nil {
+		log.Fatal(err)
+	}
 
 	deriveCmd.Flags().StringVar(&RootKey, "key", "", "extended root key")
 	deriveCmd.Flags().StringSliceVar(&Paths, "paths", []string{}, "key HD derivation paths")
@@ -60,6 +170,42 @@ func AddFlag() {
 		log.Fatal(err)
 	}
 	deriveCmd.Flags().StringVar(&Token, "token", "", "token")
+	deriveCmd.Flags().StringVar(&TokenRegistry, "token-registry", "", "token registry file (JSON or YAML), defines additional tokens")
+	deriveCmd.Flags().BoolVar(&Descriptors, "descriptors", false,
+		"generate pkh, sh(wpkh), wpkh and tr output descriptors of paths, supported tokens: BTC, XTN")
+	deriveCmd.Flags().StringVar(&ExtendedKeyVersion, "extended-key-version", "", extendedKeyVersionUsage)
+	deriveCmd.Flags().StringVar(&Output, "output", OutputText, "output format on stdout, supported formats: text, json, jsonl")
+}
+
+// addGroupFilesFlags adds flags of recovery group files, listed files or files discovered in recovery dir.
+func addGroupFilesFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&GroupFiles, "recovery-group-files", []string{},
+		"TSS recovery group files, such as recovery/recovery-secrets-node1-time1,recovery/recovery-secrets-node2-time2")
+	cmd.Flags().StringVar(&RecoveryDir, "recovery-dir", "",
+		"recovery dir, discover recovery group files of the group in this directory, instead of recovery group files")
+	cmd.MarkFlagsMutuallyExclusive("recovery-group-files", "recovery-dir")
+	cmd.MarkFlagsOneRequired("recovery-group-files", "recovery-dir")
+}
+
+// addRootPrivateKeyFlags adds flags of root private key source, recovery group files or extended root private key.
+func addRootPrivateKeyFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&GroupFiles, "recovery-group-files", []string{},
+		"TSS recovery group files, such as recovery/recovery-secrets-node1-time1,recovery/recovery-secrets-node2-time2")
+	cmd.Flags().StringVar(&RecoveryDir, "recovery-dir", "",
+		"recovery dir, discover recovery group files of the group in this directory, instead of recovery group files")
+	cmd.Flags().StringVar(&GroupID, "group-id", "", "recovery group id")
+	cmd.Flags().StringVar(&RootKey, "key", "", "extended root private key, instead of recovery group files")
+	cmd.Flags().StringArrayVar(&PassphraseSources, "passphrase-source", []string{}, passphraseSourceUsage)
+	cmd.MarkFlagsMutuallyExclusive("key", "recovery-group-files", "recovery-dir")
+	cmd.MarkFlagsOneRequired("key", "recovery-group-files", "recovery-dir")
+}
+
+// addMessageFlags adds flags of message to sign or verify, message text or message file.
+func addMessageFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&Message, "message", "", "message text")
+	cmd.Flags().StringVar(&MessageFile, "message-file", "", "message file, raw bytes or EIP-712 typed data JSON")
+	cmd.MarkFlagsMutuallyExclusive("message", "message-file")
+	cmd.MarkFlagsOneRequired("message", "message-file")
 }
 
 var rootCmd = &cobra.Command{
@@ -67,12 +213,14 @@ var rootCmd = &cobra.Command{
 	Short: "Reconstruct root private key by TSS recovery group files and derive child keys",
 	Args:  cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Version: " + version.TextVersion() + "\n")
+		initOutput()
+		printVersion()
 		err := checkFlags()
 		if err != nil {
 			log.Fatal("Check flags failed: ", err)
 		}
 		recovery()
+		flushRecords()
 	},
 }
 
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/wallet"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/version"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	TxFile string
+	HDPath string
+)
+
+var signEVMTxCmd = &cobra.Command{
+	Use:   "sign-evm-tx",
+	Short: "Sign EVM transaction by the child key derived from the root key by TSS recovery group files or extended private key",
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("Version: " + version.TextVersion() + "\n")
+		signEVMTx()
+	},
+}
+
+func signEVMTx() {
+	txBytes, err := os.ReadFile(filepath.Clean(TxFile))
+	if err != nil {
+		log.Fatalf("Read transaction file %v failed: %v", TxFile, err)
+	}
+	evmTx := &wallet.EVMTransaction{}
+	if err := json.Unmarshal(txBytes, evmTx); err != nil {
+		log.Fatalf("Parse transaction file %v failed: %v", TxFile, err)
+	}
+
+	key := rootPrivateKey()
+	dk, err := crypto.Derive(key, HDPath)
+	if err != nil {
+		log.Fatalf("Derive key failed: %v", err)
+	}
+	addresses, err := wallet.GenerateEVMAddress(dk)
+	if err != nil {
+		log.Fatalf("Generate address failed: %v", err)
+	}
+	log.Printf("Signing address: %v", addresses[0].Address)
+
+	tx, err := wallet.SignEVMTransaction(dk, evmTx)
+	if err != nil {
+		log.Fatalf("Sign transaction failed: %v", err)
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		log.Fatalf("Encode signed transaction failed: %v", err)
+	}
+	fmt.Printf("Signed transaction: %v\n", hexutil.Encode(raw))
+	fmt.Printf("Transaction hash: %v\n", tx.Hash().Hex())
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/utils"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/wallet"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/version"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	Curve       string
+	Scheme      string
+	AddressType string
+	Message     string
+	MessageFile string
+)
+
+var signMessageCmd = &cobra.Command{
+	Use:   "sign-message",
+	Short: "Sign message by the child key derived from the root key by TSS recovery group files or extended private key",
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("Version: " + version.TextVersion() + "\n")
+		signMessage()
+	},
+}
+
+func signMessage() {
+	curve, exists := crypto.CurveNameType[Curve]
+	if !exists {
+		log.Fatalf("Curve %v not support", Curve)
+	}
+	switch {
+	case curve == crypto.ED25519 && Scheme != "":
+		log.Fatalf("Scheme %v not support by curve %v", Scheme, Curve)
+	case curve == crypto.SECP256K1 && Scheme != wallet.EIP191 && Scheme != wallet.EIP712 && Scheme != wallet.BIP137:
+		log.Fatalf("Scheme %q not support by curve %v", Scheme, Curve)
+	}
+	loadTokenRegistry()
+	message := readMessage()
+
+	key := rootPrivateKey()
+	dk, err := crypto.Derive(key, HDPath)
+	if err != nil {
+		log.Fatalf("Derive key failed: %v", err)
+	}
+	if (curve == crypto.ED25519) != (dk.GetType() == crypto.EDDSAKey) {
+		log.Fatalf("Root key curve mismatch %v", Curve)
+	}
+
+	var address wallet.Address
+	var signature string
+	switch {
+	case curve == crypto.ED25519:
+		sig, err := crypto.SignEDDSA(dk, message)
+		if err != nil {
+			log.Fatalf("Sign message failed: %v", err)
+		}
+		if Token != "" {
+			addresses, err := tokenAddresses(Token, dk)
+			if err != nil {
+				log.Fatalf("Generate address failed: %v", err)
+			}
+			address = addresses[0]
+		}
+		fmt.Printf("Public key: %v\n", utils.Encode(dk.PublicKey().GetKey()[1:]))
+		signature = utils.Encode(sig)
+	case Scheme == wallet.EIP191:
+		var sig []byte
+		address, sig, err = wallet.SignEIP191Message(dk, message)
+		if err != nil {
+			log.Fatalf("Sign message failed: %v", err)
+		}
+		signature = utils.Encode(sig)
+	case Scheme == wallet.EIP712:
+		var sig []byte
+		address, sig, err = wallet.SignEIP712TypedData(dk, message)
+		if err != nil {
+			log.Fatalf("Sign typed data failed: %v", err)
+		}
+		signature = utils.Encode(sig)
+	case Scheme == wallet.BIP137:
+		networkName := Token
+		if networkName == "" {
+			networkName = "BTC"
+		}
+		network, err := wallet.GetNetwork(networkName)
+		if err != nil {
+			log.Fatalf("Get network failed: %v", err)
+		}
+		addressType, exists := wallet.AddressTypeNames[AddressType]
+		if !exists {
+			log.Fatalf("Address type %v not support", AddressType)
+		}
+		address, signature, err = wallet.SignBIP137Message(dk, network, addressType, message)
+		if err != nil {
+			log.Fatalf("Sign message failed: %v", err)
+		}
+	}
+
+	if address.Address != "" {
+		fmt.Printf("Address: %v\n", formatAddresses([]wallet.Address{address}))
+	}
+	fmt.Printf("Signature: %v\n", signature)
+}
+
+// readMessage reads message from flag message or message file.
+func readMessage() []byte {
+	if MessageFile == "" {
+		return []byte(Message)
+	}
+	message, err := os.ReadFile(filepath.Clean(MessageFile))
+	if err != nil {
+		log.Fatalf("Read message file %v failed: %v", MessageFile, err)
+	}
+	return message
+}
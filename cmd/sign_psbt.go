@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/wallet"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/version"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	PSBTFile       string
+	PSBTOutputFile string
+)
+
+var signPSBTCmd = &cobra.Command{
+	Use:   "sign-psbt",
+	Short: "Sign BIP174 PSBT inputs derived from the root key by TSS recovery group files or extended private key",
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("Version: " + version.TextVersion() + "\n")
+		signPSBT()
+	},
+}
+
+func signPSBT() {
+	psbtBytes, err := os.ReadFile(filepath.Clean(PSBTFile))
+	if err != nil {
+		log.Fatalf("Read psbt file %v failed: %v", PSBTFile, err)
+	}
+	// binary psbt starts with magic bytes, otherwise base64 encoded
+	isBase64 := !bytes.HasPrefix(psbtBytes, []byte("psbt\xff"))
+	if isBase64 {
+		psbtBytes = bytes.TrimSpace(psbtBytes)
+	}
+	packet, err := psbt.NewFromRawBytes(bytes.NewReader(psbtBytes), isBase64)
+	if err != nil {
+		log.Fatalf("Parse psbt file %v failed: %v", PSBTFile, err)
+	}
+
+	key := rootPrivateKey()
+	signed, err := wallet.SignPSBT(packet, key)
+	if err != nil {
+		log.Fatalf("Sign psbt failed: %v", err)
+	}
+	if len(signed) == 0 {
+		log.Fatal("No psbt input derived from the root key")
+	}
+	log.Printf("Signed psbt inputs: %v", signed)
+
+	signedPSBT, err := packet.B64Encode()
+	if err != nil {
+		log.Fatalf("Encode signed psbt failed: %v", err)
+	}
+	// existing output file is never overwritten
+	writeFile, err := os.OpenFile(filepath.Clean(PSBTOutputFile), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		log.Fatalf("Create signed psbt file %v failed, please backup and remove: %v", PSBTOutputFile, err)
+	}
+	defer writeFile.Close()
+	if _, err := writeFile.WriteString(signedPSBT); err != nil {
+		log.Fatalf("Write signed psbt file %v failed: %v", PSBTOutputFile, err)
+	}
+	log.Printf("Signed psbt saved to %v", PSBTOutputFile)
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/wallet"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/version"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var signSolanaTxCmd = &cobra.Command{
+	Use:   "sign-solana-tx",
+	Short: "Sign Solana transfer transaction by the fee payer key derived from the root key by TSS recovery group files or extended private key",
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("Version: " + version.TextVersion() + "\n")
+		signSolanaTx()
+	},
+}
+
+func signSolanaTx() {
+	txBytes, err := os.ReadFile(filepath.Clean(TxFile))
+	if err != nil {
+		log.Fatalf("Read transfer file %v failed: %v", TxFile, err)
+	}
+	transfer := &wallet.SolanaTransfer{}
+	if err := json.Unmarshal(txBytes, transfer); err != nil {
+		log.Fatalf("Parse transfer file %v failed: %v", TxFile, err)
+	}
+
+	key := rootPrivateKey()
+	dk, err := crypto.Derive(key, transfer.FeePayerPath)
+	if err != nil {
+		log.Fatalf("Derive fee payer key failed: %v", err)
+	}
+	addresses, err := wallet.GenerateSOLAddress(dk)
+	if err != nil {
+		log.Fatalf("Generate address failed: %v", err)
+	}
+	log.Printf("Fee payer address: %v", addresses[0].Address)
+
+	tx, err := wallet.SignSolanaTransfer(dk, transfer)
+	if err != nil {
+		log.Fatalf("Sign transaction failed: %v", err)
+	}
+	fmt.Printf("Signed transaction: %v\n", base64.StdEncoding.EncodeToString(tx))
+}
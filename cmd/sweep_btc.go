@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/wallet"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/version"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	UTXOFile    string
+	Destination string
+	FeeRate     int64
+)
+
+var sweepBTCCmd = &cobra.Command{
+	Use:   "sweep-btc",
+	Short: "Build and sign BTC/XTN transaction spending all UTXOs to destination address",
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("Version: " + version.TextVersion() + "\n")
+		sweepBTC()
+	},
+}
+
+func sweepBTC() {
+	if Token == "" {
+		Token = wallet.BTC.Name
+	}
+	if Token != wallet.BTC.Name && Token != wallet.XTN.Name {
+		log.Fatalf("Token %v not support, supported tokens: BTC, XTN", Token)
+	}
+	network, err := wallet.GetNetwork(Token)
+	if err != nil {
+		log.Fatalf("Get network failed: %v", err)
+	}
+	utxoBytes, err := os.ReadFile(filepath.Clean(UTXOFile))
+	if err != nil {
+		log.Fatalf("Read utxo file %v failed: %v", UTXOFile, err)
+	}
+	var utxos []*wallet.UTXO
+	if err := json.Unmarshal(utxoBytes, &utxos); err != nil {
+		log.Fatalf("Parse utxo file %v failed: %v", UTXOFile, err)
+	}
+
+	key := rootPrivateKey()
+	sweep, err := wallet.BuildSweepTransaction(key, network.Params, utxos, Destination, FeeRate)
+	if err != nil {
+		log.Fatalf("Build sweep transaction failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := sweep.Tx.Serialize(&buf); err != nil {
+		log.Fatalf("Serialize sweep transaction failed: %v", err)
+	}
+	log.Printf("Sweep %v utxos to %v, amount: %v, fee: %v, vsize: %v",
+		len(utxos), Destination, sweep.Tx.TxOut[0].Value, sweep.Fee, sweep.VSize)
+	fmt.Printf("Signed transaction: %v\n", hex.EncodeToString(buf.Bytes()))
+	fmt.Printf("Transaction id: %v\n", sweep.Tx.TxHash())
+}
@@ -1,14 +1,10 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
 	"os"
 	"path/filepath"
 
-	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/cipher"
 	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/tss"
-	"github.com/CoboGlobal/cobo-mpc-recovery-kits/version"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -18,13 +14,17 @@ var verifyCmd = &cobra.Command{
 	Short: "Reconstruct root public key by share public keys and verify TSS recovery group files parameters",
 	Args:  cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Version: " + version.TextVersion() + "\n")
+		initOutput()
+		printVersion()
 		verifyShare()
+		flushRecords()
 	},
 }
 
 //nolint:gocognit
 func verifyShare() {
+	discoverGroupFiles()
+	initPassphraseProviders()
 	if len(GroupFiles) == 0 {
 		log.Fatal("no recovery group files")
 	}
@@ -45,18 +45,8 @@ func verifyShare() {
 			log.Fatalln("Read recovery group file failed:", err)
 		}
 
-		var groups []*tss.Group
-		rSecrets := tss.RecoverySecrets{RecoveryGroups: make([]*tss.Group, 0)}
-		rGroups := make([]*tss.Group, 0)
-		var rGroup tss.Group
-		if err := json.Unmarshal(groupBytes, &rSecrets); err == nil && len(rSecrets.RecoveryGroups) > 0 {
-			groups = rSecrets.RecoveryGroups
-		} else if err := json.Unmarshal(groupBytes, &rGroups); err == nil && len(rGroups) > 0 {
-			groups = rGroups
-		} else if err := json.Unmarshal(groupBytes, &rGroup); err == nil {
-			rGroups = append(rGroups, &rGroup)
-			groups = rGroups
-		} else {
+		groups, err := tss.ParseRecoveryGroups(groupBytes)
+		if err != nil {
 			log.Fatalf("Cannot parse recovery group file: %v", groupFile)
 		}
 
@@ -93,16 +83,22 @@ func verifyShare() {
 		log.Printf("Verify to reconstruct root public key passed!")
 
 		log.Printf("Start to derive share public key from share secret ...")
-		fmt.Printf("Enter password to decrypt share secret from %v\n", groupFile)
-		key, err := cipher.Credentials("Password:")
-		if err != nil {
-			log.Fatalln("Credentials error:", err)
-		}
+		key := groupFilePassphrase(groupFile)
 		if err := group.VerifySharePublicKey(key); err != nil {
 			log.Fatalln("Verify share public key failed:", err)
 		}
 		log.Printf("Verify to derive share public key from share secret passed!")
 		log.Printf("Verify recovery group file %v passed!", groupFile)
+		emitRecord(&Record{
+			Type:                  RecordGroup,
+			GroupID:               group.GroupInfo.ID,
+			File:                  groupFile,
+			NodeID:                group.ShareInfo.NodeID,
+			Curve:                 group.GroupInfo.Curve,
+			Threshold:             group.GroupInfo.Threshold,
+			RootExtendedPublicKey: group.GroupInfo.RootExtendedPubKey,
+			Status:                "verified",
+		})
 		log.Printf("=======================================")
 	}
 	log.Printf("Verify all recovery group files passed!")
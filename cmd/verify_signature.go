@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/version"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	PublicKey string
+	Signature string
+)
+
+var verifySignatureCmd = &cobra.Command{
+	Use:   "verify-signature",
+	Short: "Verify message signature by public key",
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("Version: " + version.TextVersion() + "\n")
+		verifySignature()
+	},
+}
+
+func verifySignature() {
+	curve, exists := crypto.CurveNameType[Curve]
+	if !exists {
+		log.Fatalf("Curve %v not support", Curve)
+	}
+	message := readMessage()
+
+	pubKey, err := decodeHex(PublicKey)
+	if err != nil {
+		log.Fatalf("Decode public key failed: %v", err)
+	}
+	signature, err := decodeHex(Signature)
+	if err != nil {
+		log.Fatalf("Decode signature failed: %v", err)
+	}
+
+	var valid bool
+	switch curve {
+	case crypto.ED25519:
+		valid, err = crypto.VerifyEDDSA(pubKey, message, signature)
+	default:
+		err = fmt.Errorf("curve %v not support", Curve)
+	}
+	if err != nil {
+		log.Fatalf("Verify signature failed: %v", err)
+	}
+	if !valid {
+		log.Fatal("Signature is invalid")
+	}
+	log.Printf("Signature is valid")
+}
+
+// decodeHex decodes hex string with or without 0x prefix.
+func decodeHex(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s = s[2:]
+	}
+	return hex.DecodeString(s)
+}
@@ -2,6 +2,7 @@ package cipher
 
 import (
 	"fmt"
+	"os"
 	"strings"
 	"syscall"
 
@@ -9,13 +10,22 @@ import (
 )
 
 func Credentials(prompt string) (string, error) {
-	fmt.Print(prompt)
+	// fail fast instead of blocking scripts without terminal
+	if !term.IsTerminal(int(syscall.Stdin)) { //nolint:unconvert
+		return "", fmt.Errorf("stdin is not a terminal, set a non-interactive passphrase source")
+	}
+	// prompt on stderr, stdout is kept for command output
+	fmt.Fprint(os.Stderr, prompt)
 	bytePassword, err := term.ReadPassword(int(syscall.Stdin)) //nolint:unconvert
-	fmt.Print("\n")
+	fmt.Fprint(os.Stderr, "\n")
 	if err != nil {
 		return "", fmt.Errorf("error read password from terminal: %w", err)
 	}
-	password := string(bytePassword)
+	return checkPassword(string(bytePassword))
+}
+
+// checkPassword trims the password and checks it is not too short.
+func checkPassword(password string) (string, error) {
 	password = strings.TrimSpace(password)
 	if password == "" {
 		return "", fmt.Errorf("null password is not allowed")
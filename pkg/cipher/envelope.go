@@ -0,0 +1,79 @@
+package cipher
+
+import (
+	"crypto"
+	// EnvelopeKDFHash is registered by the import, otherwise PBKDF2 panics in binaries not linking it
+	_ "crypto/sha256"
+	"fmt"
+)
+
+// Envelope format of data encrypted with passphrase.
+const (
+	EnvelopeVersion       = 1
+	EnvelopeCipher        = "aes-256-gcm"
+	EnvelopeKeyLength     = 32
+	EnvelopeKDFIterations = 600000
+	EnvelopeKDFHash       = crypto.SHA256
+	// EnvelopeMinKDFIterations is the least iterations accepted when opening an envelope.
+	EnvelopeMinKDFIterations = 100000
+)
+
+// Envelope is the JSON format of data encrypted by AES256GCM with the key derived from passphrase by PBKDF2 of KDF,
+// Ciphertext is nonce || sealed data || tag, base64 encoded in JSON.
+type Envelope struct {
+	Version    int    `json:"version"`
+	Cipher     string `json:"cipher"`
+	KDF        *KDF   `json:"kdf"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// SealEnvelope encrypts data with passphrase and a freshly generated KDF.
+func SealEnvelope(passphrase string, data []byte) (*Envelope, error) {
+	kdf := NewKDF(EnvelopeKeyLength, EnvelopeKDFIterations, EnvelopeKDFHash)
+	if kdf == nil {
+		return nil, fmt.Errorf("generate KDF failed")
+	}
+	aesGCM, err := NewAES256GCMWithPassPhrase(passphrase, kdf)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := aesGCM.Encrypt(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Envelope{
+		Version:    EnvelopeVersion,
+		Cipher:     EnvelopeCipher,
+		KDF:        kdf,
+		Ciphertext: ciphertext,
+	}, nil
+}
+
+// Open decrypts data of the envelope with passphrase.
+func (e *Envelope) Open(passphrase string) ([]byte, error) {
+	if e == nil || e.KDF == nil {
+		return nil, fmt.Errorf("envelope KDF nil")
+	}
+	if e.Version != EnvelopeVersion || e.Cipher != EnvelopeCipher {
+		return nil, fmt.Errorf("envelope version %v cipher %v not support", e.Version, e.Cipher)
+	}
+	if e.KDF.Length != EnvelopeKeyLength {
+		return nil, fmt.Errorf("envelope KDF length %v not support", e.KDF.Length)
+	}
+	// KDF is read from the file, an unavailable hash panics in PBKDF2
+	if e.KDF.HashType != EnvelopeKDFHash {
+		return nil, fmt.Errorf("envelope KDF hash type %v not support", e.KDF.HashType)
+	}
+	if e.KDF.Iterations < EnvelopeMinKDFIterations {
+		return nil, fmt.Errorf("envelope KDF iterations %v less than %v", e.KDF.Iterations, EnvelopeMinKDFIterations)
+	}
+	aesGCM, err := NewAES256GCMWithPassPhrase(passphrase, e.KDF)
+	if err != nil {
+		return nil, err
+	}
+	data, err := aesGCM.Decrypt(e.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("AES GCM decrypt error: %v", err)
+	}
+	return data, nil
+}
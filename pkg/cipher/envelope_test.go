@@ -0,0 +1,64 @@
+package cipher
+
+import (
+	"crypto"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvelope(t *testing.T) {
+	data := []byte("wallet name,coin,address\n")
+	envelope, err := SealEnvelope("testpassword", data)
+	assert.NoError(t, err)
+	assert.Equal(t, EnvelopeVersion, envelope.Version)
+	assert.Equal(t, EnvelopeKeyLength, envelope.KDF.Length)
+	assert.NotContains(t, string(envelope.Ciphertext), string(data))
+
+	envelopeJSON, err := json.Marshal(envelope)
+	assert.NoError(t, err)
+	opened := &Envelope{}
+	assert.NoError(t, json.Unmarshal(envelopeJSON, opened))
+	decrypted, err := opened.Open("testpassword")
+	assert.NoError(t, err)
+	assert.Equal(t, data, decrypted)
+
+	_, err = opened.Open("wrongpassword")
+	assert.Error(t, err)
+	another, err := SealEnvelope("testpassword", data)
+	assert.NoError(t, err)
+	assert.NotEqual(t, envelope.KDF.Salt, another.KDF.Salt)
+
+	opened.Ciphertext[len(opened.Ciphertext)-1] ^= 1
+	_, err = opened.Open("testpassword")
+	assert.Error(t, err)
+	opened.Version = 2
+	_, err = opened.Open("testpassword")
+	assert.Error(t, err)
+}
+
+func TestEnvelopeKDF(t *testing.T) {
+	envelope, err := SealEnvelope("testpassword", []byte("wallet name,coin,address\n"))
+	assert.NoError(t, err)
+	envelopeJSON, err := json.Marshal(envelope)
+	assert.NoError(t, err)
+
+	// crafted KDF of the file is rejected before deriving the key
+	for _, hashType := range []crypto.Hash{0, crypto.MD4, crypto.SHA1, crypto.SHA512, 99} {
+		opened := &Envelope{}
+		assert.NoError(t, json.Unmarshal(envelopeJSON, opened))
+		opened.KDF.HashType = hashType
+		assert.NotPanics(t, func() {
+			_, err = opened.Open("testpassword")
+		})
+		assert.ErrorContains(t, err, "hash type")
+	}
+	for _, iterations := range []int{-1, 0, 1, EnvelopeMinKDFIterations - 1} {
+		opened := &Envelope{}
+		assert.NoError(t, json.Unmarshal(envelopeJSON, opened))
+		opened.KDF.Iterations = iterations
+		_, err = opened.Open("testpassword")
+		assert.ErrorContains(t, err, "iterations")
+	}
+}
@@ -0,0 +1,174 @@
+package cipher
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Passphrase source kinds, a source is "tty" or "<kind>:<value>", such as env:RECOVERY_PASSWORD.
+const (
+	PassphraseSourceTTY  = "tty"
+	PassphraseSourceEnv  = "env"
+	PassphraseSourceFile = "file"
+	PassphraseSourceFD   = "fd"
+	PassphraseSourceJSON = "json"
+)
+
+// PassphraseProvider provides the passphrase of a recovery group file.
+type PassphraseProvider interface {
+	// Passphrase returns the passphrase of the group file, prompt is only shown by interactive provider.
+	Passphrase(groupFile string, prompt string) (string, error)
+	// Interactive is true if the passphrase is typed in terminal.
+	Interactive() bool
+	String() string
+}
+
+// TTYPassphrase reads the passphrase from terminal without echo.
+type TTYPassphrase struct{}
+
+func (p *TTYPassphrase) Passphrase(_ string, prompt string) (string, error) {
+	return Credentials(prompt)
+}
+
+func (p *TTYPassphrase) Interactive() bool { return true }
+
+func (p *TTYPassphrase) String() string { return PassphraseSourceTTY }
+
+// EnvPassphrase reads the passphrase from environment variable.
+type EnvPassphrase struct {
+	Name string
+}
+
+func (p *EnvPassphrase) Passphrase(_ string, _ string) (string, error) {
+	passphrase, ok := os.LookupEnv(p.Name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %v not set", p.Name)
+	}
+	return checkPassword(passphrase)
+}
+
+func (p *EnvPassphrase) Interactive() bool { return false }
+
+func (p *EnvPassphrase) String() string { return PassphraseSourceEnv + ":" + p.Name }
+
+// FilePassphrase reads the passphrase from the first line of file.
+type FilePassphrase struct {
+	Path string
+}
+
+func (p *FilePassphrase) Passphrase(_ string, _ string) (string, error) {
+	file, err := os.Open(p.Path)
+	if err != nil {
+		return "", fmt.Errorf("open passphrase file error: %w", err)
+	}
+	defer file.Close()
+	line, err := readPassphraseLine(bufio.NewReader(file))
+	if err != nil {
+		return "", fmt.Errorf("read passphrase file %v error: %w", p.Path, err)
+	}
+	return checkPassword(line)
+}
+
+func (p *FilePassphrase) Interactive() bool { return false }
+
+func (p *FilePassphrase) String() string { return PassphraseSourceFile + ":" + p.Path }
+
+// FDPassphrase reads passphrases line by line from an inherited file descriptor,
+// group files sharing the descriptor read successive lines.
+type FDPassphrase struct {
+	FD     uintptr
+	reader *bufio.Reader
+}
+
+func (p *FDPassphrase) Passphrase(_ string, _ string) (string, error) {
+	if p.reader == nil {
+		file := os.NewFile(p.FD, "fd"+strconv.FormatUint(uint64(p.FD), 10))
+		if file == nil {
+			return "", fmt.Errorf("file descriptor %v is invalid", p.FD)
+		}
+		p.reader = bufio.NewReader(file)
+	}
+	line, err := readPassphraseLine(p.reader)
+	if err != nil {
+		return "", fmt.Errorf("read passphrase from file descriptor %v error: %w", p.FD, err)
+	}
+	return checkPassword(line)
+}
+
+func (p *FDPassphrase) Interactive() bool { return false }
+
+func (p *FDPassphrase) String() string {
+	return PassphraseSourceFD + ":" + strconv.FormatUint(uint64(p.FD), 10)
+}
+
+// JSONPassphrase reads the passphrase of group file from a JSON map file of group file to passphrase,
+// group file is matched by the path, then by the base name.
+type JSONPassphrase struct {
+	Path        string
+	passphrases map[string]string
+}
+
+func (p *JSONPassphrase) Passphrase(groupFile string, _ string) (string, error) {
+	if p.passphrases == nil {
+		data, err := os.ReadFile(p.Path)
+		if err != nil {
+			return "", fmt.Errorf("read passphrase map file error: %w", err)
+		}
+		passphrases := make(map[string]string)
+		if err := json.Unmarshal(data, &passphrases); err != nil {
+			return "", fmt.Errorf("passphrase map file %v is not JSON object of file to passphrase: %w", p.Path, err)
+		}
+		p.passphrases = passphrases
+	}
+	for _, name := range []string{groupFile, filepath.Clean(groupFile), filepath.Base(groupFile)} {
+		if passphrase, ok := p.passphrases[name]; ok {
+			return checkPassword(passphrase)
+		}
+	}
+	return "", fmt.Errorf("passphrase of %v not found in passphrase map file %v", groupFile, p.Path)
+}
+
+func (p *JSONPassphrase) Interactive() bool { return false }
+
+func (p *JSONPassphrase) String() string { return PassphraseSourceJSON + ":" + p.Path }
+
+// ParsePassphraseSource parses source "tty", "env:<name>", "file:<path>", "fd:<number>" or "json:<path>".
+func ParsePassphraseSource(source string) (PassphraseProvider, error) {
+	if source == PassphraseSourceTTY {
+		return &TTYPassphrase{}, nil
+	}
+	kind, value, found := strings.Cut(source, ":")
+	if !found || value == "" {
+		return nil, fmt.Errorf("passphrase source %v is invalid, supported sources: tty, env:<name>, file:<path>, fd:<number>, json:<path>",
+			source)
+	}
+	switch kind {
+	case PassphraseSourceEnv:
+		return &EnvPassphrase{Name: value}, nil
+	case PassphraseSourceFile:
+		return &FilePassphrase{Path: value}, nil
+	case PassphraseSourceFD:
+		fd, err := strconv.ParseUint(value, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("passphrase source %v file descriptor is invalid: %w", source, err)
+		}
+		return &FDPassphrase{FD: uintptr(fd)}, nil
+	case PassphraseSourceJSON:
+		return &JSONPassphrase{Path: value}, nil
+	default:
+		return nil, fmt.Errorf("passphrase source kind %v not support, supported kinds: tty, env, file, fd, json", kind)
+	}
+}
+
+func readPassphraseLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
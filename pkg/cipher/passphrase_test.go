@@ -0,0 +1,84 @@
+package cipher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePassphraseSource(t *testing.T) {
+	for source, expected := range map[string]PassphraseProvider{
+		"tty":                 &TTYPassphrase{},
+		"env:RECOVERY_PWD":    &EnvPassphrase{Name: "RECOVERY_PWD"},
+		"file:/tmp/pwd.txt":   &FilePassphrase{Path: "/tmp/pwd.txt"},
+		"fd:3":                &FDPassphrase{FD: 3},
+		"json:passwords.json": &JSONPassphrase{Path: "passwords.json"},
+	} {
+		provider, err := ParsePassphraseSource(source)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, provider)
+		assert.Equal(t, source, provider.String())
+		assert.Equal(t, source == PassphraseSourceTTY, provider.Interactive())
+	}
+	for _, source := range []string{"", "env", "env:", "fd:-1", "fd:three", "stdin:1"} {
+		_, err := ParsePassphraseSource(source)
+		assert.Error(t, err, source)
+	}
+}
+
+func TestEnvPassphrase(t *testing.T) {
+	t.Setenv("COBO_TEST_PASSPHRASE", " testpassword\n")
+	passphrase, err := (&EnvPassphrase{Name: "COBO_TEST_PASSPHRASE"}).Passphrase("recovery-secrets-node1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "testpassword", passphrase)
+
+	t.Setenv("COBO_TEST_PASSPHRASE", "short")
+	_, err = (&EnvPassphrase{Name: "COBO_TEST_PASSPHRASE"}).Passphrase("recovery-secrets-node1", "")
+	assert.Error(t, err)
+	_, err = (&EnvPassphrase{Name: "COBO_TEST_PASSPHRASE_NOT_SET"}).Passphrase("recovery-secrets-node1", "")
+	assert.Error(t, err)
+}
+
+func TestFilePassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "passphrase")
+	assert.NoError(t, os.WriteFile(path, []byte("testpassword\r\nsecond line\n"), 0o600))
+	passphrase, err := (&FilePassphrase{Path: path}).Passphrase("recovery-secrets-node1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "testpassword", passphrase)
+
+	assert.NoError(t, os.WriteFile(path, []byte(""), 0o600))
+	_, err = (&FilePassphrase{Path: path}).Passphrase("recovery-secrets-node1", "")
+	assert.Error(t, err)
+	_, err = (&FilePassphrase{Path: path + ".missing"}).Passphrase("recovery-secrets-node1", "")
+	assert.Error(t, err)
+}
+
+func TestJSONPassphrase(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "passphrases.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{
+		"recovery/recovery-secrets-node1": "testpassword1",
+		"recovery-secrets-node2": "testpassword2",
+		"recovery-secrets-node3": "short"
+	}`), 0o600))
+	provider := &JSONPassphrase{Path: path}
+	passphrase, err := provider.Passphrase("recovery/recovery-secrets-node1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "testpassword1", passphrase)
+	passphrase, err = provider.Passphrase("./recovery/recovery-secrets-node1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "testpassword1", passphrase)
+	passphrase, err = provider.Passphrase("/data/recovery/recovery-secrets-node2", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "testpassword2", passphrase)
+	_, err = provider.Passphrase("recovery-secrets-node3", "")
+	assert.Error(t, err)
+	_, err = provider.Passphrase("recovery-secrets-node4", "")
+	assert.Error(t, err)
+
+	assert.NoError(t, os.WriteFile(path, []byte(`["testpassword"]`), 0o600))
+	_, err = (&JSONPassphrase{Path: path}).Passphrase("recovery-secrets-node1", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,33 @@
+//go:build unix
+
+package cipher
+
+import (
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFDPassphrase(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	assert.NoError(t, err)
+	defer reader.Close()
+	// provider owns a duplicated descriptor, reader is closed only once
+	fd, err := syscall.Dup(int(reader.Fd()))
+	assert.NoError(t, err)
+	_, err = writer.WriteString("testpassword1\ntestpassword2")
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	provider := &FDPassphrase{FD: uintptr(fd)}
+	passphrase, err := provider.Passphrase("recovery-secrets-node1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "testpassword1", passphrase)
+	passphrase, err = provider.Passphrase("recovery-secrets-node2", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "testpassword2", passphrase)
+	_, err = provider.Passphrase("recovery-secrets-node3", "")
+	assert.Error(t, err)
+}
@@ -36,6 +36,12 @@ func Derive(key CKDKey, path string) (CKDKey, error) {
 	if err != nil {
 		return nil, err
 	}
+	return DeriveIndexes(key, indexes)
+}
+
+// DeriveIndexes derives child key by child indexes of the HD path.
+func DeriveIndexes(key CKDKey, indexes []uint32) (CKDKey, error) {
+	var err error
 	dk := key
 	for _, index := range indexes {
 		dk, err = dk.NewChildKey(index)
@@ -46,6 +52,23 @@ func Derive(key CKDKey, path string) (CKDKey, error) {
 	return dk, nil
 }
 
+// Fingerprint returns the first 4 bytes of hash160 of the key's compressed public key.
+func Fingerprint(key CKDKey) ([]byte, error) {
+	if key == nil || key.PublicKey() == nil {
+		return nil, fmt.Errorf("key is nil")
+	}
+	hash, err := hash160(key.PublicKey().GetKey())
+	if err != nil {
+		return nil, err
+	}
+	return hash[:4], nil
+}
+
+// ParsePath parses the HD path to child indexes, hardened index is marked by ' or H suffix.
+func ParsePath(path string) ([]uint32, error) {
+	return parsePath(path)
+}
+
 func parsePath(path string) ([]uint32, error) {
 	path = strings.TrimSpace(strings.ReplaceAll(path, " ", ""))
 	path = strings.TrimPrefix(path, "m")
@@ -94,5 +117,5 @@ func B58Deserialize(data string) (CKDKey, error) {
 		return B58DeserializeECDSAExtendedKey(data)
 	}
 
-	return nil, fmt.Errorf("error key version 0x%x", id)
+	return deserializeECDSAExtendedKeyWithVersion(b)
 }
@@ -0,0 +1,72 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"crypto/sha512"
+	"fmt"
+	"math/big"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/utils"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+)
+
+// EDDSA private keys are scalars rather than RFC 8032 seeds, so there is no seed prefix to derive
+// the signing nonce from. The nonce is derived deterministically from the scalar and the message instead:
+// r = SHA-512(scalar (little endian) || message) mod L
+// Signatures are standard Ed25519 signatures and verify with any RFC 8032 implementation.
+
+// SignEDDSA signs the message with the EDDSA private key scalar, returns 64 bytes Ed25519 signature.
+func SignEDDSA(key CKDKey, message []byte) ([]byte, error) {
+	if key == nil {
+		return nil, fmt.Errorf("key is nil")
+	}
+	if key.GetType() != EDDSAKey || !key.IsPrivateKey() {
+		return nil, fmt.Errorf("key is not eddsa private key")
+	}
+	privKey, err := CreateEDDSAPrivateKey(new(big.Int).SetBytes(key.GetKey()))
+	if err != nil {
+		return nil, err
+	}
+
+	scalar := utils.BigIntTo32BytesBE(privKey.GetD())
+	scalarLE := reverseBytes(scalar[:])
+	h := sha512.New()
+	h.Write(scalarLE)
+	h.Write(message)
+	nonce := new(big.Int).Mod(new(big.Int).SetBytes(reverseBytes(h.Sum(nil))), Edwards().Params().N)
+	if nonce.Sign() == 0 {
+		return nil, fmt.Errorf("nonce is zero")
+	}
+	nonceBytes := utils.BigIntTo32BytesBE(nonce)
+
+	r, s, err := edwards.SignFromScalar(privKey, nonceBytes[:], message)
+	if err != nil {
+		return nil, err
+	}
+	return edwards.NewSignature(r, s).Serialize(), nil
+}
+
+// VerifyEDDSA verifies the Ed25519 signature of message, public key is 32 bytes or 33 bytes with 0x00 prefix.
+func VerifyEDDSA(pubKey []byte, message []byte, signature []byte) (bool, error) {
+	if len(pubKey) == 33 {
+		if pubKey[0] != 0x00 {
+			return false, fmt.Errorf("eddsa public key prefix 0x%x is invalid", pubKey[0])
+		}
+		pubKey = pubKey[1:]
+	}
+	if len(pubKey) != ed25519.PublicKeySize {
+		return false, fmt.Errorf("eddsa public key length %v is invalid", len(pubKey))
+	}
+	if len(signature) != ed25519.SignatureSize {
+		return false, fmt.Errorf("eddsa signature length %v is invalid", len(signature))
+	}
+	return ed25519.Verify(pubKey, message, signature), nil
+}
+
+func reverseBytes(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
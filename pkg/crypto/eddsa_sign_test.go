@@ -0,0 +1,54 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignEDDSA(t *testing.T) {
+	// RFC 8032 test 1 secret key, as scalar reduced mod L
+	d, _ := new(big.Int).SetString("0fe94d9006f020a5a3c080d96827fffce8852346655006e96ae99be612ac2c7c", 16)
+	privKey, err := CreateEDDSAPrivateKey(d)
+	assert.NoError(t, err)
+	key := CreateEDDSAExtendedPrivateKey(privKey, make([]byte, 32))
+	pubKey := key.PublicKey().GetKey()
+	assert.Equal(t, "00d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a", hex.EncodeToString(pubKey))
+
+	message := []byte("cobo mpc recovery")
+	signature, err := SignEDDSA(key, message)
+	assert.NoError(t, err)
+	assert.Len(t, signature, ed25519.SignatureSize)
+	assert.True(t, ed25519.Verify(pubKey[1:], message, signature))
+
+	// deterministic nonce
+	signature2, err := SignEDDSA(key, message)
+	assert.NoError(t, err)
+	assert.Equal(t, signature, signature2)
+
+	valid, err := VerifyEDDSA(pubKey, message, signature)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	valid, err = VerifyEDDSA(pubKey[1:], []byte("other message"), signature)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+	_, err = VerifyEDDSA(pubKey[2:], message, signature)
+	assert.Error(t, err)
+
+	// child keys
+	root, err := B58Deserialize("cprv3NNjUWyx1RBi3H5V8GgxywS8GRLt6PntM2dkf8ZeRfmBukJ2iYs1fsoDcXeXGstHPH18FufK9z2KyRRpW2eh3MwhgHNd7VDCPuvU6pYsoig")
+	assert.NoError(t, err)
+	child, err := Derive(root, "m/44/501/0/0")
+	assert.NoError(t, err)
+	signature, err = SignEDDSA(child, message)
+	assert.NoError(t, err)
+	valid, err = VerifyEDDSA(child.PublicKey().GetKey(), message, signature)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	_, err = SignEDDSA(child.PublicKey(), message)
+	assert.Error(t, err)
+}
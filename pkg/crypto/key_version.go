@@ -0,0 +1,96 @@
+package crypto
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/bip32"
+)
+
+// ExtendedKeyVersion is the version bytes of serialized extended private and public keys, Name is the public key prefix.
+type ExtendedKeyVersion struct {
+	Name    string
+	Private [4]byte
+	Public  [4]byte
+}
+
+// SLIP-132 and testnet versions of ECDSA extended keys.
+var (
+	XPubVersion      = ExtendedKeyVersion{Name: "xpub", Private: [4]byte{0x04, 0x88, 0xad, 0xe4}, Public: [4]byte{0x04, 0x88, 0xb2, 0x1e}}
+	YPubVersion      = ExtendedKeyVersion{Name: "ypub", Private: [4]byte{0x04, 0x9d, 0x78, 0x78}, Public: [4]byte{0x04, 0x9d, 0x7c, 0xb2}}
+	ZPubVersion      = ExtendedKeyVersion{Name: "zpub", Private: [4]byte{0x04, 0xb2, 0x43, 0x0c}, Public: [4]byte{0x04, 0xb2, 0x47, 0x46}}
+	UpperYPubVersion = ExtendedKeyVersion{Name: "Ypub", Private: [4]byte{0x02, 0x95, 0xb0, 0x05}, Public: [4]byte{0x02, 0x95, 0xb4, 0x3f}}
+	UpperZPubVersion = ExtendedKeyVersion{Name: "Zpub", Private: [4]byte{0x02, 0xaa, 0x7a, 0x99}, Public: [4]byte{0x02, 0xaa, 0x7e, 0xd3}}
+	TPubVersion      = ExtendedKeyVersion{Name: "tpub", Private: [4]byte{0x04, 0x35, 0x83, 0x94}, Public: [4]byte{0x04, 0x35, 0x87, 0xcf}}
+	UPubVersion      = ExtendedKeyVersion{Name: "upub", Private: [4]byte{0x04, 0x4a, 0x4e, 0x28}, Public: [4]byte{0x04, 0x4a, 0x52, 0x62}}
+	VPubVersion      = ExtendedKeyVersion{Name: "vpub", Private: [4]byte{0x04, 0x5f, 0x18, 0xbc}, Public: [4]byte{0x04, 0x5f, 0x1c, 0xf6}}
+	UpperUPubVersion = ExtendedKeyVersion{Name: "Upub", Private: [4]byte{0x02, 0x42, 0x85, 0xb5}, Public: [4]byte{0x02, 0x42, 0x89, 0xef}}
+	UpperVPubVersion = ExtendedKeyVersion{Name: "Vpub", Private: [4]byte{0x02, 0x57, 0x50, 0x48}, Public: [4]byte{0x02, 0x57, 0x54, 0x83}}
+)
+
+// ExtendedKeyVersions contains ECDSA extended key versions by name.
+var ExtendedKeyVersions = map[string]ExtendedKeyVersion{
+	XPubVersion.Name:      XPubVersion,
+	YPubVersion.Name:      YPubVersion,
+	ZPubVersion.Name:      ZPubVersion,
+	UpperYPubVersion.Name: UpperYPubVersion,
+	UpperZPubVersion.Name: UpperZPubVersion,
+	TPubVersion.Name:      TPubVersion,
+	UPubVersion.Name:      UPubVersion,
+	VPubVersion.Name:      VPubVersion,
+	UpperUPubVersion.Name: UpperUPubVersion,
+	UpperVPubVersion.Name: UpperVPubVersion,
+}
+
+// B58SerializeWithVersion encodes the ECDSA extended key in base58 with the private or public version bytes of version.
+func B58SerializeWithVersion(key CKDKey, version ExtendedKeyVersion) (string, error) {
+	if key == nil {
+		return "", fmt.Errorf("key is nil")
+	}
+	if key.GetType() != ECDSAKey {
+		return "", fmt.Errorf("key is not ecdsa key")
+	}
+	serializedKey, err := key.Serialize()
+	if err != nil {
+		return "", err
+	}
+	// replace version bytes and checksum
+	data := make([]byte, 0, len(serializedKey))
+	if key.IsPrivateKey() {
+		data = append(data, version.Private[:]...)
+	} else {
+		data = append(data, version.Public[:]...)
+	}
+	data = append(data, serializedKey[4:len(serializedKey)-4]...)
+	data, err = addChecksumToBytes(data)
+	if err != nil {
+		return "", err
+	}
+	return base58Encode(data), nil
+}
+
+// deserializeECDSAExtendedKeyWithVersion decodes the ECDSA extended key of SLIP-132 or testnet version,
+// the version of the key is reset to bip32 version.
+func deserializeECDSAExtendedKeyWithVersion(data []byte) (CKDKey, error) {
+	id := data[0:4]
+	for _, version := range ExtendedKeyVersions {
+		isPrivate := bytes.Equal(id, version.Private[:])
+		if !isPrivate && !bytes.Equal(id, version.Public[:]) {
+			continue
+		}
+		key, err := bip32.Deserialize(data)
+		if err != nil {
+			return nil, err
+		}
+		if key.IsPrivate != isPrivate {
+			return nil, fmt.Errorf("key version 0x%x mismatch key data", id)
+		}
+		if isPrivate {
+			key.Version = bip32.PrivateWalletVersion
+		} else {
+			key.Version = bip32.PublicWalletVersion
+		}
+		return NewECDSAExtendedKey(key), nil
+	}
+	return nil, fmt.Errorf("error key version 0x%x", id)
+}
@@ -0,0 +1,43 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestB58SerializeWithVersion(t *testing.T) {
+	// BIP-84 account key of mnemonic "abandon ... about"
+	zpub := "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs"
+	xpub := "xpub6CatWdiZiodmUeTDp8LT5or8nmbKNcuyvz7WyksVFkKB4RHwCD3XyuvPEbvqAQY3rAPshWcMLoP2fMFMKHPJ4ZeZXYVUhLv1VMrjPC7PW6V"
+	key, err := B58Deserialize(zpub)
+	assert.NoError(t, err)
+	assert.Equal(t, xpub, key.String())
+	serialized, err := B58SerializeWithVersion(key, ZPubVersion)
+	assert.NoError(t, err)
+	assert.Equal(t, zpub, serialized)
+
+	root, err := B58Deserialize("xprv9s21ZrQH143K3GJpoapnV8SFfukcVBSfeCficPSGfubmSFDxo1kuHnLisriDvSnRRuL2Qrg5ggqHKNVpxR86QEC8w35uxmGoggxtQTPvfUu")
+	assert.NoError(t, err)
+	for name, version := range ExtendedKeyVersions {
+		serialized, err := B58SerializeWithVersion(root.PublicKey(), version)
+		assert.NoError(t, err)
+		assert.Equal(t, name, serialized[:4])
+		key, err := B58Deserialize(serialized)
+		assert.NoError(t, err)
+		assert.Equal(t, root.PublicKey().String(), key.String())
+
+		serialized, err = B58SerializeWithVersion(root, version)
+		assert.NoError(t, err)
+		assert.Equal(t, name[:1]+"prv", serialized[:4])
+		key, err = B58Deserialize(serialized)
+		assert.NoError(t, err)
+		assert.True(t, key.IsPrivateKey())
+		assert.Equal(t, root.String(), key.String())
+	}
+
+	eddsaKey, err := B58Deserialize("cprv3NNjUWyx1RBi3H5V8GgxywS8GRLt6PntM2dkf8ZeRfmBukJ2iYs1fsoDcXeXGstHPH18FufK9z2KyRRpW2eh3MwhgHNd7VDCPuvU6pYsoig")
+	assert.NoError(t, err)
+	_, err = B58SerializeWithVersion(eddsaKey, TPubVersion)
+	assert.Error(t, err)
+}
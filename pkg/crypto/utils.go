@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"strings"
 
 	"github.com/FactomProject/basen"
 	// #nosec G507 -- RIPEMD160 is required by BIP32 standard
@@ -17,6 +18,11 @@ import (
 // BitcoinBase58Encoding is the encoding used for bitcoin addresses.
 var BitcoinBase58Encoding = basen.NewEncoding("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
 
+const rippleBase58Alphabet = "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz"
+
+// RippleBase58Encoding is the encoding used for XRP Ledger addresses.
+var RippleBase58Encoding = basen.NewEncoding(rippleBase58Alphabet)
+
 //
 // Hashes
 //
@@ -95,6 +101,25 @@ func base58Decode(data string) ([]byte, error) {
 	return BitcoinBase58Encoding.DecodeString(data)
 }
 
+// Hash160 returns RIPEMD160(SHA256(data)).
+func Hash160(data []byte) ([]byte, error) {
+	return hash160(data)
+}
+
+// RippleBase58CheckEncode encodes version and payload with double sha256 checksum in ripple base58 encoding,
+// each leading zero byte is encoded as the first alphabet character.
+func RippleBase58CheckEncode(version byte, payload []byte) (string, error) {
+	data, err := addChecksumToBytes(append([]byte{version}, payload...))
+	if err != nil {
+		return "", err
+	}
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
+	}
+	return strings.Repeat(rippleBase58Alphabet[:1], zeros) + RippleBase58Encoding.EncodeToString(data), nil
+}
+
 func addPrivateKeys(curve elliptic.Curve, key1 []byte, key2 []byte) []byte {
 	var key1Int big.Int
 	var key2Int big.Int
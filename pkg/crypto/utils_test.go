@@ -0,0 +1,20 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRippleBase58CheckEncode(t *testing.T) {
+	address, err := RippleBase58CheckEncode(0x00, make([]byte, 20))
+	assert.NoError(t, err)
+	assert.Equal(t, "rrrrrrrrrrrrrrrrrrrrrhoLvTp", address)
+
+	accountID, err := utils.Decode("0x7f7e1a2344ca1ae3c4b7d616adf16fa607f8931f")
+	assert.NoError(t, err)
+	address, err = RippleBase58CheckEncode(0x00, accountID)
+	assert.NoError(t, err)
+	assert.Equal(t, "rUdftaDqhVNCsKHk6C8yjn86M1AVE5QF8i", address)
+}
@@ -0,0 +1,139 @@
+package tss
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GroupFilePrefix is the name prefix of recovery group files, recovery-secrets-<node id>-<export time>.
+const GroupFilePrefix = "recovery-secrets-"
+
+// exportTimeLayouts are layouts of export time in recovery group file name, besides unix seconds and milliseconds.
+var exportTimeLayouts = []string{time.RFC3339, "20060102150405", "20060102-150405", "2006-01-02-15-04-05"}
+
+// GroupFile is a group parsed from a recovery group file, ModTime is the modification time of the file.
+type GroupFile struct {
+	File    string
+	ModTime time.Time
+	Group   *Group
+}
+
+// NodeGroupFiles are the group files of a node in a group, Selected is the latest exported file,
+// Duplicates contain the same share as Selected, Stale contain other shares of the node and are exported earlier.
+type NodeGroupFiles struct {
+	NodeID     string
+	Selected   *GroupFile
+	Duplicates []*GroupFile
+	Stale      []*GroupFile
+}
+
+// ParseRecoveryGroups parses recovery group file data of RecoverySecrets, []Group or Group JSON.
+func ParseRecoveryGroups(data []byte) ([]*Group, error) {
+	rSecrets := RecoverySecrets{RecoveryGroups: make([]*Group, 0)}
+	rGroups := make([]*Group, 0)
+	var rGroup Group
+	if err := json.Unmarshal(data, &rSecrets); err == nil && len(rSecrets.RecoveryGroups) > 0 {
+		return rSecrets.RecoveryGroups, nil
+	} else if err := json.Unmarshal(data, &rGroups); err == nil && len(rGroups) > 0 {
+		return rGroups, nil
+	} else if err := json.Unmarshal(data, &rGroup); err == nil && rGroup.GroupInfo != nil {
+		return append(rGroups, &rGroup), nil
+	}
+	return nil, fmt.Errorf("cannot parse recovery group data")
+}
+
+// GroupNodeFiles groups the group files by group ID and node ID, nodes of a group are sorted by node ID.
+// Files of a node are ordered by export time in the file name, modification time is only the last tiebreaker,
+// which is reset by copy or restore. Files of groups without group info or share info are ignored.
+func GroupNodeFiles(files []*GroupFile) map[string][]*NodeGroupFiles {
+	nodeFiles := make(map[string]map[string][]*GroupFile)
+	for _, file := range files {
+		if file == nil || file.Group == nil || file.Group.GroupInfo == nil || file.Group.ShareInfo == nil {
+			continue
+		}
+		groupID, nodeID := file.Group.GroupInfo.ID, file.Group.ShareInfo.NodeID
+		if nodeFiles[groupID] == nil {
+			nodeFiles[groupID] = make(map[string][]*GroupFile)
+		}
+		nodeFiles[groupID][nodeID] = append(nodeFiles[groupID][nodeID], file)
+	}
+
+	groups := make(map[string][]*NodeGroupFiles, len(nodeFiles))
+	for groupID, nodes := range nodeFiles {
+		for nodeID, files := range nodes {
+			// latest exported first
+			sort.SliceStable(files, func(i, j int) bool {
+				exportTimeI, okI := GroupFileExportTime(files[i].File, nodeID)
+				exportTimeJ, okJ := GroupFileExportTime(files[j].File, nodeID)
+				if okI && okJ && !exportTimeI.Equal(exportTimeJ) {
+					return exportTimeI.After(exportTimeJ)
+				}
+				if okI != okJ {
+					return okI
+				}
+				if !files[i].ModTime.Equal(files[j].ModTime) {
+					return files[i].ModTime.After(files[j].ModTime)
+				}
+				return files[i].File > files[j].File
+			})
+			node := &NodeGroupFiles{NodeID: nodeID, Selected: files[0]}
+			selectedShare := files[0].Group.ShareInfo
+			for _, file := range files[1:] {
+				share := file.Group.ShareInfo
+				if share.ShareID == selectedShare.ShareID && share.SharePubKey == selectedShare.SharePubKey {
+					node.Duplicates = append(node.Duplicates, file)
+				} else {
+					node.Stale = append(node.Stale, file)
+				}
+			}
+			groups[groupID] = append(groups[groupID], node)
+		}
+		sort.Slice(groups[groupID], func(i, j int) bool {
+			return groups[groupID][i].NodeID < groups[groupID][j].NodeID
+		})
+	}
+	return groups
+}
+
+// GroupFileExportTime parses export time in the name of recovery group file recovery-secrets-<node id>-<export time>,
+// export time is unix seconds, unix milliseconds or one of exportTimeLayouts.
+func GroupFileExportTime(file string, nodeID string) (time.Time, bool) {
+	name := filepath.Base(file)
+	if exportTime, found := strings.CutPrefix(name, GroupFilePrefix+nodeID+"-"); found {
+		return parseExportTime(exportTime)
+	}
+	// node id in file name differs from share info, try suffixes after each dash from the longest
+	name, found := strings.CutPrefix(name, GroupFilePrefix)
+	for found {
+		var exportTime string
+		if _, exportTime, found = strings.Cut(name, "-"); found {
+			if t, ok := parseExportTime(exportTime); ok {
+				return t, true
+			}
+			name = exportTime
+		}
+	}
+	return time.Time{}, false
+}
+
+func parseExportTime(exportTime string) (time.Time, bool) {
+	if seconds, err := strconv.ParseInt(exportTime, 10, 64); err == nil {
+		switch len(exportTime) {
+		case 10:
+			return time.Unix(seconds, 0), true
+		case 13:
+			return time.UnixMilli(seconds), true
+		}
+	}
+	for _, layout := range exportTimeLayouts {
+		if t, err := time.Parse(layout, exportTime); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
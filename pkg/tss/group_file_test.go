@@ -0,0 +1,97 @@
+package tss
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRecoveryGroups(t *testing.T) {
+	for _, data := range []string{
+		`{"recovery_groups":[{"version":2,"group_info":{"id":"group1"}}]}`,
+		`[{"version":2,"group_info":{"id":"group1"}}]`,
+		`{"version":2,"group_info":{"id":"group1"}}`,
+	} {
+		groups, err := ParseRecoveryGroups([]byte(data))
+		if err != nil {
+			t.Fatalf("parse %v failed: %v", data, err)
+		}
+		if len(groups) != 1 || groups[0].GroupInfo == nil || groups[0].GroupInfo.ID != "group1" {
+			t.Fatalf("parse %v groups error", data)
+		}
+	}
+	for _, data := range []string{
+		"recovery",
+		`{"version":1,"cipher":"aes-256-gcm","kdf":{"length":32},"ciphertext":""}`,
+		`{"tokens":[{"name":"ARBITRUM_ETH","family":"evm"}]}`,
+		`{"address":"cd2a3d9f938e13cd947ec05abc7fe734df8dd826","crypto":{},"version":3}`,
+	} {
+		if _, err := ParseRecoveryGroups([]byte(data)); err == nil {
+			t.Fatalf("parse %v should fail", data)
+		}
+	}
+}
+
+func TestGroupFileExportTime(t *testing.T) {
+	exportTime := time.Unix(1700000000, 0)
+	for _, file := range []string{
+		"recovery/recovery-secrets-node1-1700000000",
+		"recovery-secrets-node1-1700000000000",
+		"recovery-secrets-node1-2023-11-14T22:13:20Z",
+		"recovery-secrets-node1-20231114221320",
+		"recovery-secrets-other-node-20231114-221320",
+	} {
+		parsed, ok := GroupFileExportTime(file, "node1")
+		if !ok || !parsed.Equal(exportTime) {
+			t.Fatalf("parse export time of %v error: %v", file, parsed)
+		}
+	}
+	for _, file := range []string{"recovery-secrets-node1-time1", "recovery-secrets-node1-1", "address-1700000000.csv"} {
+		if _, ok := GroupFileExportTime(file, "node1"); ok {
+			t.Fatalf("parse export time of %v should fail", file)
+		}
+	}
+}
+
+func TestGroupNodeFiles(t *testing.T) {
+	now := time.Now()
+	newGroupFile := func(file string, groupID string, nodeID string, shareID string, modTime time.Time) *GroupFile {
+		return &GroupFile{
+			File:    file,
+			ModTime: modTime,
+			Group: &Group{
+				GroupInfo: &GroupInfo{ID: groupID},
+				ShareInfo: &ShareInfo{NodeID: nodeID, ShareID: shareID},
+			},
+		}
+	}
+	// stale file of node1 is copied later, export time in file name takes precedence over modification time
+	files := []*GroupFile{
+		newGroupFile("recovery-secrets-node2-1", "group1", "node2", "share2", now.Add(-time.Hour)),
+		newGroupFile("recovery-secrets-node1-1700000100", "group1", "node1", "share1", now.Add(-time.Hour)),
+		newGroupFile("backup/recovery-secrets-node1-1700000200", "group1", "node1", "share1", now.Add(-2*time.Hour)),
+		newGroupFile("recovery-secrets-node1-1700000000", "group1", "node1", "share0", now),
+		newGroupFile("recovery-secrets-node1-3", "group2", "node1", "share3", now),
+		{File: "address.csv", Group: &Group{}},
+	}
+
+	groups := GroupNodeFiles(files)
+	if len(groups) != 2 || len(groups["group2"]) != 1 {
+		t.Fatalf("group files by group id error")
+	}
+	nodes := groups["group1"]
+	if len(nodes) != 2 || nodes[0].NodeID != "node1" || nodes[1].NodeID != "node2" {
+		t.Fatalf("group files by node id error")
+	}
+	if nodes[0].Selected.File != "backup/recovery-secrets-node1-1700000200" {
+		t.Fatalf("selected file %v error", nodes[0].Selected.File)
+	}
+	if len(nodes[0].Duplicates) != 1 || nodes[0].Duplicates[0].File != "recovery-secrets-node1-1700000100" {
+		t.Fatalf("duplicate files error")
+	}
+	if len(nodes[0].Stale) != 1 || nodes[0].Stale[0].File != "recovery-secrets-node1-1700000000" {
+		t.Fatalf("stale files error")
+	}
+	if nodes[1].Selected.File != "recovery-secrets-node2-1" || len(nodes[1].Duplicates)+len(nodes[1].Stale) != 0 {
+		t.Fatalf("node2 files error")
+	}
+}
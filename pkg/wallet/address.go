@@ -1,30 +1,68 @@
 package wallet
 
 import (
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/hex"
 	"fmt"
+	"strings"
 
 	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/utils"
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/base58"
+	"github.com/btcsuite/btcd/btcutil/bech32"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/txscript"
 	gethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
 )
 
 const (
 	LEGACY        = "Legacy"
 	NESTED_SEGWIT = "Nested SegWit (P2SH)"
 	NATIVE_SEGWIT = "Native SegWit (Bech32)"
-	TAPROOT       = "Taproot"
+	TAPROOT       = "Taproot (untweaked)"
+	TAPROOT_BIP86 = "Taproot (BIP86)"
+	CASHADDR      = "CashAddr"
 )
 
+// AddressTypeNames maps short names of bitcoin address types used in flags and JSON files to address types.
+var AddressTypeNames = map[string]string{
+	"legacy":        LEGACY,
+	"nested-segwit": NESTED_SEGWIT,
+	"native-segwit": NATIVE_SEGWIT,
+	"taproot":       TAPROOT,
+	"taproot-bip86": TAPROOT_BIP86,
+}
+
+// Ed25519 single signature scheme flags, hashed together with the public key
+// by Move chains: Aptos SHA3-256(pubkey || 0x00), Sui BLAKE2b-256(0x00 || pubkey).
+const (
+	APTOS_ED25519_SCHEME byte = 0x00
+	SUI_ED25519_FLAG     byte = 0x00
+)
+
+// Address is a generated address of a token, Type is empty for account based chains.
 type Address struct {
 	Type    string
 	Address string
 }
 
 func PubKeyToBTCAddr(key crypto.CKDKey, network *chaincfg.Params) ([]Address, error) {
-	if key == nil || network == nil {
+	if network == nil {
+		return nil, fmt.Errorf("network or key is nil")
+	}
+	return PubKeyToNetworkAddr(key, &Network{Params: network, SegWit: true, Taproot: true})
+}
+
+// PubKeyToNetworkAddr generates addresses of bitcoin-like chain,
+// address types not supported by the network are skipped.
+func PubKeyToNetworkAddr(key crypto.CKDKey, network *Network) ([]Address, error) {
+	if key == nil || network == nil || network.Params == nil {
 		return nil, fmt.Errorf("network or key is nil")
 	}
 
@@ -41,49 +79,100 @@ func PubKeyToBTCAddr(key crypto.CKDKey, network *chaincfg.Params) ([]Address, er
 	var addresses []Address
 
 	// LEGACY address
-	p2pkh, err := btcutil.NewAddressPubKey(pubBytes, network)
+	p2pkh, err := btcutil.NewAddressPubKey(pubBytes, network.Params)
 	if err != nil {
 		return addresses, err
 	}
 	addresses = append(addresses, Address{Type: LEGACY, Address: p2pkh.EncodeAddress()})
 
-	// NATIVE_SEGWIT address
-	p2wpkh, err := btcutil.NewAddressWitnessPubKeyHash(btcutil.Hash160(pubBytes), network)
-	if err != nil {
-		return addresses, err
+	// CASHADDR address
+	if network.CashAddrPrefix != "" {
+		cashAddr, err := EncodeCashAddr(network.CashAddrPrefix, CashAddrP2PKH, btcutil.Hash160(pubBytes))
+		if err != nil {
+			return addresses, err
+		}
+		addresses = append(addresses, Address{Type: CASHADDR, Address: cashAddr})
 	}
-	addresses = append(addresses, Address{Type: NATIVE_SEGWIT, Address: p2wpkh.EncodeAddress()})
 
-	// NESTED_SEGWIT address
-	redeemScript, err := txscript.PayToAddrScript(p2wpkh)
-	if err != nil {
-		return addresses, err
-	}
-	p2sh, err := btcutil.NewAddressScriptHash(redeemScript, network)
-	if err != nil {
-		return addresses, err
+	if network.SegWit {
+		// NATIVE_SEGWIT address
+		p2wpkh, err := btcutil.NewAddressWitnessPubKeyHash(btcutil.Hash160(pubBytes), network.Params)
+		if err != nil {
+			return addresses, err
+		}
+		addresses = append(addresses, Address{Type: NATIVE_SEGWIT, Address: p2wpkh.EncodeAddress()})
+
+		// NESTED_SEGWIT address
+		redeemScript, err := txscript.PayToAddrScript(p2wpkh)
+		if err != nil {
+			return addresses, err
+		}
+		p2sh, err := btcutil.NewAddressScriptHash(redeemScript, network.Params)
+		if err != nil {
+			return addresses, err
+		}
+		addresses = append(addresses, Address{Type: NESTED_SEGWIT, Address: p2sh.EncodeAddress()})
 	}
-	addresses = append(addresses, Address{Type: NESTED_SEGWIT, Address: p2sh.EncodeAddress()})
 
-	// TAPROOT address
-	// p2tr, err := btcutil.NewAddressTaproot(txscript.ComputeTaprootKeyNoScript(publicKey).SerializeCompressed()[1:], network)
-	p2tr, err := btcutil.NewAddressTaproot(publicKey.SerializeCompressed()[1:], network)
-	if err != nil {
-		return addresses, err
+	if network.Taproot {
+		// TAPROOT address, output key is the untweaked internal key
+		p2tr, err := btcutil.NewAddressTaproot(publicKey.SerializeCompressed()[1:], network.Params)
+		if err != nil {
+			return addresses, err
+		}
+		addresses = append(addresses, Address{Type: TAPROOT, Address: p2tr.EncodeAddress()})
+
+		// TAPROOT_BIP86 address, output key is the internal key tweaked without script path
+		p2trBIP86, err := btcutil.NewAddressTaproot(
+			schnorr.SerializePubKey(txscript.ComputeTaprootKeyNoScript(publicKey)), network.Params)
+		if err != nil {
+			return addresses, err
+		}
+		addresses = append(addresses, Address{Type: TAPROOT_BIP86, Address: p2trBIP86.EncodeAddress()})
 	}
-	addresses = append(addresses, Address{Type: TAPROOT, Address: p2tr.EncodeAddress()})
 
 	return addresses, nil
 }
 
+// MatchAddress finds the generated address equal to address, returns the matched address type.
+// Hex addresses are compared case-insensitively, CashAddr addresses may omit the prefix.
+func MatchAddress(addresses []Address, address string) (Address, bool) {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return Address{}, false
+	}
+	for _, addr := range addresses {
+		if addr.Address == address {
+			return addr, true
+		}
+		if strings.HasPrefix(address, "0x") && strings.EqualFold(addr.Address, address) {
+			return addr, true
+		}
+		if addr.Type == CASHADDR && strings.HasSuffix(addr.Address, ":"+address) {
+			return addr, true
+		}
+	}
+	return Address{}, false
+}
+
 func GenerateXTNAddresses(key crypto.CKDKey) ([]Address, error) {
-	network := &chaincfg.TestNet3Params
-	return PubKeyToBTCAddr(key, network)
+	return PubKeyToNetworkAddr(key, &XTNNetwork)
 }
 
 func GenerateBTCAddresses(key crypto.CKDKey) ([]Address, error) {
-	network := &chaincfg.MainNetParams
-	return PubKeyToBTCAddr(key, network)
+	return PubKeyToNetworkAddr(key, &BTCNetwork)
+}
+
+func GenerateLTCAddresses(key crypto.CKDKey) ([]Address, error) {
+	return PubKeyToNetworkAddr(key, &LTCNetwork)
+}
+
+func GenerateDOGEAddresses(key crypto.CKDKey) ([]Address, error) {
+	return PubKeyToNetworkAddr(key, &DOGENetwork)
+}
+
+func GenerateBCHAddresses(key crypto.CKDKey) ([]Address, error) {
+	return PubKeyToNetworkAddr(key, &BCHNetwork)
 }
 
 func GenerateEVMAddress(key crypto.CKDKey) ([]Address, error) {
@@ -106,3 +195,214 @@ func GenerateEVMAddress(key crypto.CKDKey) ([]Address, error) {
 		},
 	}, nil
 }
+
+func GenerateTRXAddress(key crypto.CKDKey) ([]Address, error) {
+	if key == nil {
+		return nil, fmt.Errorf("key is nil")
+	}
+
+	if key.GetType() != crypto.ECDSAKey {
+		return nil, fmt.Errorf("key is not ecdsa key")
+	}
+
+	publicKey, err := crypto.DecompressECDSAPubKey(key.PublicKey().GetKey())
+	if err != nil {
+		return nil, err
+	}
+	// base58check of 0x41 prefix and last 20 bytes of keccak256 hash of uncompressed public key
+	return []Address{
+		{
+			Type:    "",
+			Address: base58.CheckEncode(gethCrypto.PubkeyToAddress(*publicKey).Bytes(), 0x41),
+		},
+	}, nil
+}
+
+func GenerateXRPAddress(key crypto.CKDKey) ([]Address, error) {
+	if key == nil {
+		return nil, fmt.Errorf("key is nil")
+	}
+
+	if key.GetType() != crypto.ECDSAKey {
+		return nil, fmt.Errorf("key is not ecdsa key")
+	}
+
+	publicKey, err := crypto.DecompressECDSAPubKey(key.PublicKey().GetKey())
+	if err != nil {
+		return nil, err
+	}
+	// account ID is RIPEMD160(SHA256(compressed public key)), encoded with 0x00 type prefix
+	accountID, err := crypto.Hash160(crypto.CompressECDSAPubKey(publicKey))
+	if err != nil {
+		return nil, err
+	}
+	address, err := crypto.RippleBase58CheckEncode(0x00, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return []Address{
+		{
+			Type:    "",
+			Address: address,
+		},
+	}, nil
+}
+
+// PubKeyToCosmosAddr encodes bech32 address with human-readable prefix hrp.
+// Standard Cosmos SDK chains hash RIPEMD160(SHA256(compressed public key)),
+// Ethermint chains hash keccak256(uncompressed public key) the same way as EVM.
+func PubKeyToCosmosAddr(key crypto.CKDKey, hrp string, ethermint bool) ([]Address, error) {
+	if key == nil || hrp == "" {
+		return nil, fmt.Errorf("hrp or key is nil")
+	}
+
+	if key.GetType() != crypto.ECDSAKey {
+		return nil, fmt.Errorf("key is not ecdsa key")
+	}
+
+	publicKey, err := crypto.DecompressECDSAPubKey(key.PublicKey().GetKey())
+	if err != nil {
+		return nil, err
+	}
+
+	var hash []byte
+	if ethermint {
+		hash = gethCrypto.PubkeyToAddress(*publicKey).Bytes()
+	} else {
+		hash = btcutil.Hash160(crypto.CompressECDSAPubKey(publicKey))
+	}
+	conv, err := bech32.ConvertBits(hash, 8, 5, true)
+	if err != nil {
+		return nil, err
+	}
+	address, err := bech32.Encode(hrp, conv)
+	if err != nil {
+		return nil, err
+	}
+	return []Address{
+		{
+			Type:    "",
+			Address: address,
+		},
+	}, nil
+}
+
+func GenerateSOLAddress(key crypto.CKDKey) ([]Address, error) {
+	publicKey, err := eddsaPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return []Address{
+		{
+			Type:    "",
+			Address: base58.Encode(publicKey),
+		},
+	}, nil
+}
+
+func GenerateAPTAddress(key crypto.CKDKey) ([]Address, error) {
+	publicKey, err := eddsaPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha3.Sum256(append(publicKey, APTOS_ED25519_SCHEME))
+	return []Address{
+		{
+			Type:    "",
+			Address: utils.Encode(hash[:]),
+		},
+	}, nil
+}
+
+func GenerateSUIAddress(key crypto.CKDKey) ([]Address, error) {
+	publicKey, err := eddsaPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	hash := blake2b.Sum256(append([]byte{SUI_ED25519_FLAG}, publicKey...))
+	return []Address{
+		{
+			Type:    "",
+			Address: utils.Encode(hash[:]),
+		},
+	}, nil
+}
+
+func GenerateXLMAddress(key crypto.CKDKey) ([]Address, error) {
+	publicKey, err := eddsaPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	// StrKey: base32 of version byte 6 << 3 (G), public key and CRC16-XModem checksum in little endian
+	payload := append([]byte{6 << 3}, publicKey...)
+	checksum := crc16XModem(payload)
+	payload = append(payload, byte(checksum), byte(checksum>>8))
+	return []Address{
+		{
+			Type:    "",
+			Address: base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(payload),
+		},
+	}, nil
+}
+
+func GenerateALGOAddress(key crypto.CKDKey) ([]Address, error) {
+	publicKey, err := eddsaPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	// base32 of public key and last 4 bytes of SHA512/256 checksum
+	checksum := sha512.Sum512_256(publicKey)
+	payload := append(publicKey, checksum[len(checksum)-4:]...)
+	return []Address{
+		{
+			Type:    "",
+			Address: base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(payload),
+		},
+	}, nil
+}
+
+func GenerateNEARAddress(key crypto.CKDKey) ([]Address, error) {
+	publicKey, err := eddsaPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	// implicit account is lowercase hex of public key
+	return []Address{
+		{
+			Type:    "",
+			Address: hex.EncodeToString(publicKey),
+		},
+	}, nil
+}
+
+// eddsaPublicKey returns 32 bytes ed25519 public key of key.
+func eddsaPublicKey(key crypto.CKDKey) ([]byte, error) {
+	if key == nil {
+		return nil, fmt.Errorf("key is nil")
+	}
+
+	if key.GetType() != crypto.EDDSAKey {
+		return nil, fmt.Errorf("key is not eddsa key")
+	}
+
+	publicKey, err := crypto.DecompressEDDSAPubKey(key.PublicKey().GetKey())
+	if err != nil {
+		return nil, err
+	}
+	return publicKey.SerializeCompressed(), nil
+}
+
+func crc16XModem(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
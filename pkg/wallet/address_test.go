@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -14,7 +15,7 @@ func TestGenerateXTNAddress(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	assert.Len(t, addresses, 4)
+	assert.Len(t, addresses, 5)
 	assert.True(t, foundAddress(addresses, "tb1prs8ekfunf4yfq6epnz8q4zw39zykhkgz9hrprpgml4s3w04egzwsnhud60", TAPROOT))
 
 	key, err = crypto.B58Deserialize("xpub6Fu7txWcpENRqfaVLYCHo4FnoFvxkG9UQ52xMxsi5XQuXFhqp36izQrPZqDWqr3P5rGbECP3Bzoc7j9HK1ZWFJe3FBsPZZ14NaDJKfyYGa5")
@@ -23,7 +24,7 @@ func TestGenerateXTNAddress(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	assert.Len(t, addresses, 4)
+	assert.Len(t, addresses, 5)
 	assert.True(t, foundAddress(addresses, "tb1q0px4kneptpwer2qqypd6hsvwgmg9r40tz5qws0", NATIVE_SEGWIT))
 
 	key, err = crypto.B58Deserialize("xpub6Fu7txWcpENRnAtev4TYMcAwAZcNxMjr4hxGPZMu8eXgaC1HjS3dDqhaPkY6EHvzisyNa21aLnHYYZ8YQ1y3qTZSTfAvPHoh53T9ebe4CBa")
@@ -32,7 +33,7 @@ func TestGenerateXTNAddress(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	assert.Len(t, addresses, 4)
+	assert.Len(t, addresses, 5)
 	assert.True(t, foundAddress(addresses, "2MxgpWvNHAsXPRvZfxmRT2CXWAVzEuS2rtV", NESTED_SEGWIT))
 
 	key, err = crypto.B58Deserialize("xpub6Fu7txWcpENRkFuTNw1dqziPS2vH6KK9K1k6WkVVq65fZYBhRLAh5j4kVKPtXQAYCgnoLtkkLYSmYWTuGi1Fx53GumKRyDGqtpR3CM69eNf")
@@ -41,7 +42,7 @@ func TestGenerateXTNAddress(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	assert.Len(t, addresses, 4)
+	assert.Len(t, addresses, 5)
 	assert.True(t, foundAddress(addresses, "ms95B9JosWfupRmNREmMZ112DsYCBC2Xu5", LEGACY))
 }
 
@@ -52,7 +53,7 @@ func TestGenerateBTCAddress(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	assert.Len(t, addresses, 4)
+	assert.Len(t, addresses, 5)
 	assert.True(t, foundAddress(addresses, "16EwA2beT2AgiipxJBc81527KP2kSogAMw", LEGACY))
 
 	key, err = crypto.B58Deserialize("xpub6Gp6PCF54nmH2aS9w2CspDXe1KdfoQxWDJnQktWammDhjVdVSQtzvfD7pBJP7HAmQkYrjYgC4wh5z3cREYo3zUgSSCu3VeFNSJZPTs8jC8z")
@@ -61,7 +62,7 @@ func TestGenerateBTCAddress(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	assert.Len(t, addresses, 4)
+	assert.Len(t, addresses, 5)
 	assert.True(t, foundAddress(addresses, "36iDRpHScA9SLTYGkqmr2BThoDjp5w4r7x", NESTED_SEGWIT))
 
 	key, err = crypto.B58Deserialize("xpub6Gp6PCF54nmGyHJ4x3FhkwRnVGXFZkErWjYSgXHYpBKSERiTnmteAqHKDWq3VGDgE789RZ8x2fVArp9W6dQNw9HwNVHVGZYrbabSixYoAP4")
@@ -70,7 +71,7 @@ func TestGenerateBTCAddress(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	assert.Len(t, addresses, 4)
+	assert.Len(t, addresses, 5)
 	assert.True(t, foundAddress(addresses, "bc1qgkal4qh29fklm7rw07f6rw6mpxr8thjxvuc7hk", NATIVE_SEGWIT))
 
 	key, err = crypto.B58Deserialize("xpub6Gp6PCF54nmGwSdya4hyLwzaC3fLpApXCWdAuuragPRG6cxnnkJj6A9ic3sMeEzhtCyXGuLvYD4wEbnvvaiYbGk3ZsYWjE5Xcqn2KcYfZx3")
@@ -79,10 +80,100 @@ func TestGenerateBTCAddress(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	assert.Len(t, addresses, 4)
+	assert.Len(t, addresses, 5)
 	assert.True(t, foundAddress(addresses, "bc1peh6fpx7un7jfypedtnpylftl5uv92kdchqzu0ty9s354lccn8vxsfpp4jn", TAPROOT))
 }
 
+func TestGenerateBIP86TaprootAddress(t *testing.T) {
+	// BIP86 test vector m/86'/0'/0'/0/0
+	pubKey, err := utils.Decode("0x02cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115")
+	assert.NoError(t, err)
+	publicKey, err := crypto.DecompressECDSAPubKey(pubKey)
+	assert.NoError(t, err)
+	key := crypto.NewECDSAExtendedKey(crypto.CreateECDSAExtendedPublicKey(publicKey, make([]byte, 32)))
+	addresses, err := GenerateBTCAddresses(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, addresses, 5)
+	assert.True(t, foundAddress(addresses, "bc1pej9yh3jd39aam30mctm8paaghg9nsemezpk0zg3udlza0nt0cy2sqvps98", TAPROOT))
+	assert.True(t, foundAddress(addresses, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", TAPROOT_BIP86))
+
+	address, found := MatchAddress(addresses, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr")
+	assert.True(t, found)
+	assert.Equal(t, TAPROOT_BIP86, address.Type)
+	_, found = MatchAddress(addresses, "bc1qgkal4qh29fklm7rw07f6rw6mpxr8thjxvuc7hk")
+	assert.False(t, found)
+}
+
+func TestGenerateLTCAddress(t *testing.T) {
+	key, err := crypto.B58Deserialize("xpub6Gp6PCF54nmH4gWS4spcdsVSibgjBGrkuyBXVG8hCjn1Cq99uk222YPhJouQm7Gmw2bKFpEk5MGrZBD9PQTDZsBcB9qXztKUcUoXUMCBSgD")
+	assert.NoError(t, err)
+	addresses, err := GenerateLTCAddresses(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, addresses, 5)
+	assert.True(t, foundAddress(addresses, "LQTtREuUXgQjyXX7UKbRH65sXbQ2UezM8e", LEGACY))
+
+	key, err = crypto.B58Deserialize("xpub6Gp6PCF54nmGyHJ4x3FhkwRnVGXFZkErWjYSgXHYpBKSERiTnmteAqHKDWq3VGDgE789RZ8x2fVArp9W6dQNw9HwNVHVGZYrbabSixYoAP4")
+	assert.NoError(t, err)
+	addresses, err = GenerateLTCAddresses(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, addresses, 5)
+	assert.True(t, foundAddress(addresses, "ltc1qgkal4qh29fklm7rw07f6rw6mpxr8thjxgqz60x", NATIVE_SEGWIT))
+
+	// BIP86 test vector m/86'/0'/0'/0/0 encoded with litecoin hrp
+	pubKey, err := utils.Decode("0x02cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115")
+	assert.NoError(t, err)
+	publicKey, err := crypto.DecompressECDSAPubKey(pubKey)
+	assert.NoError(t, err)
+	key = crypto.NewECDSAExtendedKey(crypto.CreateECDSAExtendedPublicKey(publicKey, make([]byte, 32)))
+	addresses, err = GenerateLTCAddresses(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, foundAddress(addresses, "ltc1pej9yh3jd39aam30mctm8paaghg9nsemezpk0zg3udlza0nt0cy2srg0qlz", TAPROOT))
+	assert.True(t, foundAddress(addresses, "ltc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxq4arnzx", TAPROOT_BIP86))
+}
+
+func TestGenerateDOGEAddress(t *testing.T) {
+	key, err := crypto.B58Deserialize("xpub6Gp6PCF54nmH4gWS4spcdsVSibgjBGrkuyBXVG8hCjn1Cq99uk222YPhJouQm7Gmw2bKFpEk5MGrZBD9PQTDZsBcB9qXztKUcUoXUMCBSgD")
+	assert.NoError(t, err)
+	addresses, err := GenerateDOGEAddresses(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, addresses, 1)
+	assert.True(t, foundAddress(addresses, "DAP2hHYHkS4yFj1Z2mbgYqBiCWm3momF79", LEGACY))
+}
+
+func TestGenerateBCHAddress(t *testing.T) {
+	key, err := crypto.B58Deserialize("xpub6Gp6PCF54nmH4gWS4spcdsVSibgjBGrkuyBXVG8hCjn1Cq99uk222YPhJouQm7Gmw2bKFpEk5MGrZBD9PQTDZsBcB9qXztKUcUoXUMCBSgD")
+	assert.NoError(t, err)
+	addresses, err := GenerateBCHAddresses(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, addresses, 2)
+	assert.True(t, foundAddress(addresses, "16EwA2beT2AgiipxJBc81527KP2kSogAMw", LEGACY))
+	assert.True(t, foundAddress(addresses, "bitcoincash:qquhk0j8jm542x2l7jrvynqgcpx5vk9jey3t6aw36g", CASHADDR))
+
+	address, found := MatchAddress(addresses, "qquhk0j8jm542x2l7jrvynqgcpx5vk9jey3t6aw36g")
+	assert.True(t, found)
+	assert.Equal(t, CASHADDR, address.Type)
+}
+
+func TestEncodeCashAddr(t *testing.T) {
+	hash, err := utils.Decode("0x76a04053bda0a88bda5177b86a15c3b29f559873")
+	assert.NoError(t, err)
+	address, err := EncodeCashAddr("bitcoincash", CashAddrP2PKH, hash)
+	assert.NoError(t, err)
+	assert.Equal(t, "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", address)
+}
+
 func TestGenerateEVMAddress(t *testing.T) {
 	key, err := crypto.B58Deserialize("xpub6FXwXZ4feQjGX7ZXUdTB9cRuJuUJkzsWAQHejUBozkPgN9wwu7P7wNtuyRqiey52ES8PuZwmtgHHcVSFGH75RBthn8djN2fkdcbggtpRQQ2")
 	assert.NoError(t, err)
@@ -92,6 +183,181 @@ func TestGenerateEVMAddress(t *testing.T) {
 	}
 	assert.Len(t, addresses, 1)
 	assert.True(t, foundAddress(addresses, "0xBe7f55D105BBacc2A963aef535d0d791D8911fB2", ""))
+
+	_, found := MatchAddress(addresses, "0xbe7f55d105bbacc2a963aef535d0d791d8911fb2")
+	assert.True(t, found)
+}
+
+func TestGenerateTRXAddress(t *testing.T) {
+	key, err := crypto.B58Deserialize("xpub6FXwXZ4feQjGX7ZXUdTB9cRuJuUJkzsWAQHejUBozkPgN9wwu7P7wNtuyRqiey52ES8PuZwmtgHHcVSFGH75RBthn8djN2fkdcbggtpRQQ2")
+	assert.NoError(t, err)
+	addresses, err := GenerateTRXAddress(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, addresses, 1)
+	assert.True(t, foundAddress(addresses, "TTLTvuueJFCrcSYpuCd3tkqFP7GBWT2AHW", ""))
+
+	key, err = crypto.B58Deserialize("cpubGCmTMqXYTnzkbj4boYV9RcocrSYG1bSr8QuiRnEdhspzhvURRoBwV4iU7TnBKRRbmgHSAymckcRckZoNSR8SRK13n5ztB3pneN4xJSePBvG")
+	assert.NoError(t, err)
+	_, err = GenerateTRXAddress(key)
+	assert.Error(t, err)
+}
+
+func TestGenerateXRPAddress(t *testing.T) {
+	key, err := crypto.B58Deserialize("xpub6Fu7txWcpENRkFuTNw1dqziPS2vH6KK9K1k6WkVVq65fZYBhRLAh5j4kVKPtXQAYCgnoLtkkLYSmYWTuGi1Fx53GumKRyDGqtpR3CM69eNf")
+	assert.NoError(t, err)
+	addresses, err := GenerateXRPAddress(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, addresses, 1)
+	assert.True(t, foundAddress(addresses, "rUdftaDqhVNCsKHk6C8yjn86M1AVE5QF8i", ""))
+}
+
+func TestPubKeyToCosmosAddr(t *testing.T) {
+	key, err := crypto.B58Deserialize("xpub6Fu7txWcpENRkFuTNw1dqziPS2vH6KK9K1k6WkVVq65fZYBhRLAh5j4kVKPtXQAYCgnoLtkkLYSmYWTuGi1Fx53GumKRyDGqtpR3CM69eNf")
+	assert.NoError(t, err)
+	addresses, err := PubKeyToCosmosAddr(key, "cosmos", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, addresses, 1)
+	assert.True(t, foundAddress(addresses, "cosmos10alp5g6yegdw839h6ct2mut05crl3ycltsandd", ""))
+
+	token, err := GetToken("OSMO")
+	assert.NoError(t, err)
+	addresses, err = token.GenerateAddresses(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, foundAddress(addresses, "osmo10alp5g6yegdw839h6ct2mut05crl3yclrtwrml", ""))
+
+	key, err = crypto.B58Deserialize("xpub6FXwXZ4feQjGX7ZXUdTB9cRuJuUJkzsWAQHejUBozkPgN9wwu7P7wNtuyRqiey52ES8PuZwmtgHHcVSFGH75RBthn8djN2fkdcbggtpRQQ2")
+	assert.NoError(t, err)
+	token, err = GetToken("INJ")
+	assert.NoError(t, err)
+	addresses, err = token.GenerateAddresses(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, foundAddress(addresses, "inj1hel4t5g9hwkv92tr4m6nt5xhj8vfz8aj7rnnpu", ""))
+
+	RegisterCosmosChain("TEST_INJ", CosmosChain{HRP: "test", Ethermint: true})
+	defer delete(CosmosChainMap, "TEST_INJ")
+	token, err = GetToken("TEST_INJ")
+	assert.NoError(t, err)
+	addresses, err = token.GenerateAddresses(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, addresses, 1)
+	assert.True(t, foundAddress(addresses, "test1hel4t5g9hwkv92tr4m6nt5xhj8vfz8ajcnefls", ""))
+}
+
+func TestGenerateSOLAddress(t *testing.T) {
+	key, err := crypto.B58Deserialize("cpubGCmTMqXYTnzkbj4boYV9RcocrSYG1bSr8QuiRnEdhspzhvURRoBwV4iU7TnBKRRbmgHSAymckcRckZoNSR8SRK13n5ztB3pneN4xJSePBvG")
+	assert.NoError(t, err)
+	addresses, err := GenerateSOLAddress(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, addresses, 1)
+	assert.True(t, foundAddress(addresses, "BbkWxpNVVPPyPgK8BCfmQDSPFNJFFKW6YvdKnS8ivng5", ""))
+
+	key, err = crypto.B58Deserialize("cprv3RbSKDst833rETUMYtVj62SbNhsYev3u3XeYXrnFm8vPVXKZ5ngYs9KEBD8hEUjb9PQdMsyDqg1XdLcBz91xEyfqNrRvqkjNvF2tNzSuEHs")
+	assert.NoError(t, err)
+	addresses, err = GenerateSOLAddress(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, addresses, 1)
+	assert.True(t, foundAddress(addresses, "EYcsYYoxAYN4nSgyo7ZgjTD6ipgjdisoPMZD2j8t3rz6", ""))
+
+	key, err = crypto.B58Deserialize("xpub6FXwXZ4feQjGX7ZXUdTB9cRuJuUJkzsWAQHejUBozkPgN9wwu7P7wNtuyRqiey52ES8PuZwmtgHHcVSFGH75RBthn8djN2fkdcbggtpRQQ2")
+	assert.NoError(t, err)
+	_, err = GenerateSOLAddress(key)
+	assert.Error(t, err)
+}
+
+func TestGenerateAPTAddress(t *testing.T) {
+	key, err := crypto.B58Deserialize("cpubGCmTMqXYTnzkbj4boYV9RcocrSYG1bSr8QuiRnEdhspzhvURRoBwV4iU7TnBKRRbmgHSAymckcRckZoNSR8SRK13n5ztB3pneN4xJSePBvG")
+	assert.NoError(t, err)
+	addresses, err := GenerateAPTAddress(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, addresses, 1)
+	assert.True(t, foundAddress(addresses, "0x34575f3d4c37ace6a310ae3190a5e508bc3361d425d80f54ece1f662be52c9d9", ""))
+
+	key, err = crypto.B58Deserialize("xpub6FXwXZ4feQjGX7ZXUdTB9cRuJuUJkzsWAQHejUBozkPgN9wwu7P7wNtuyRqiey52ES8PuZwmtgHHcVSFGH75RBthn8djN2fkdcbggtpRQQ2")
+	assert.NoError(t, err)
+	_, err = GenerateAPTAddress(key)
+	assert.Error(t, err)
+}
+
+func TestGenerateSUIAddress(t *testing.T) {
+	key, err := crypto.B58Deserialize("cpubGCmTMqXYTnzkbj4boYV9RcocrSYG1bSr8QuiRnEdhspzhvURRoBwV4iU7TnBKRRbmgHSAymckcRckZoNSR8SRK13n5ztB3pneN4xJSePBvG")
+	assert.NoError(t, err)
+	addresses, err := GenerateSUIAddress(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, addresses, 1)
+	assert.True(t, foundAddress(addresses, "0xf4741e2c37adc6f2515796b6077900c3875fa94125cba356f7b54a7b81ea8d82", ""))
+
+	key, err = crypto.B58Deserialize("xpub6FXwXZ4feQjGX7ZXUdTB9cRuJuUJkzsWAQHejUBozkPgN9wwu7P7wNtuyRqiey52ES8PuZwmtgHHcVSFGH75RBthn8djN2fkdcbggtpRQQ2")
+	assert.NoError(t, err)
+	_, err = GenerateSUIAddress(key)
+	assert.Error(t, err)
+}
+
+func TestGenerateXLMAddress(t *testing.T) {
+	key, err := crypto.B58Deserialize("cpubGCmTMqXYTnzkbj4boYV9RcocrSYG1bSr8QuiRnEdhspzhvURRoBwV4iU7TnBKRRbmgHSAymckcRckZoNSR8SRK13n5ztB3pneN4xJSePBvG")
+	assert.NoError(t, err)
+	addresses, err := GenerateXLMAddress(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, addresses, 1)
+	assert.True(t, foundAddress(addresses, "GCOXXURWHIFQUA4DAMI62AT4KCPMP46MYH7GXWKLTWYMS2U3H46CNUDD", ""))
+
+	key, err = crypto.B58Deserialize("xpub6FXwXZ4feQjGX7ZXUdTB9cRuJuUJkzsWAQHejUBozkPgN9wwu7P7wNtuyRqiey52ES8PuZwmtgHHcVSFGH75RBthn8djN2fkdcbggtpRQQ2")
+	assert.NoError(t, err)
+	_, err = GenerateXLMAddress(key)
+	assert.Error(t, err)
+}
+
+func TestGenerateALGOAddress(t *testing.T) {
+	key, err := crypto.B58Deserialize("cpubGCmTMqXYTnzkbj4boYV9RcocrSYG1bSr8QuiRnEdhspzhvURRoBwV4iU7TnBKRRbmgHSAymckcRckZoNSR8SRK13n5ztB3pneN4xJSePBvG")
+	assert.NoError(t, err)
+	addresses, err := GenerateALGOAddress(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, addresses, 1)
+	assert.True(t, foundAddress(addresses, "TV55ENR2BMFAHAYDCHWQE7CQT3D7HTGB7ZV5SS45WDEWVGZ7HQTHWRQZPE", ""))
+
+	key, err = crypto.B58Deserialize("xpub6FXwXZ4feQjGX7ZXUdTB9cRuJuUJkzsWAQHejUBozkPgN9wwu7P7wNtuyRqiey52ES8PuZwmtgHHcVSFGH75RBthn8djN2fkdcbggtpRQQ2")
+	assert.NoError(t, err)
+	_, err = GenerateALGOAddress(key)
+	assert.Error(t, err)
+}
+
+func TestGenerateNEARAddress(t *testing.T) {
+	key, err := crypto.B58Deserialize("cpubGCmTMqXYTnzkbj4boYV9RcocrSYG1bSr8QuiRnEdhspzhvURRoBwV4iU7TnBKRRbmgHSAymckcRckZoNSR8SRK13n5ztB3pneN4xJSePBvG")
+	assert.NoError(t, err)
+	addresses, err := GenerateNEARAddress(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, addresses, 1)
+	assert.True(t, foundAddress(addresses, "9d7bd2363a0b0a03830311ed027c509ec7f3ccc1fe6bd94b9db0c96a9b3f3c26", ""))
+
+	key, err = crypto.B58Deserialize("xpub6FXwXZ4feQjGX7ZXUdTB9cRuJuUJkzsWAQHejUBozkPgN9wwu7P7wNtuyRqiey52ES8PuZwmtgHHcVSFGH75RBthn8djN2fkdcbggtpRQQ2")
+	assert.NoError(t, err)
+	_, err = GenerateNEARAddress(key)
+	assert.Error(t, err)
 }
 
 func foundAddress(addresses []Address, address string, addressType string) bool {
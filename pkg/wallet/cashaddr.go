@@ -0,0 +1,73 @@
+package wallet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/bech32"
+)
+
+// CashAddr encoding of Bitcoin Cash addresses.
+// https://github.com/bitcoincashorg/bitcoincash.org/blob/master/spec/cashaddr.md
+
+const (
+	cashAddrCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	CashAddrP2PKH byte = 0x00
+	CashAddrP2SH  byte = 0x08
+)
+
+func cashAddrPolyMod(values []byte) uint64 {
+	c := uint64(1)
+	for _, d := range values {
+		c0 := byte(c >> 35)
+		c = ((c & 0x07ffffffff) << 5) ^ uint64(d)
+		if c0&0x01 != 0 {
+			c ^= 0x98f2bc8e61
+		}
+		if c0&0x02 != 0 {
+			c ^= 0x79b76d99e2
+		}
+		if c0&0x04 != 0 {
+			c ^= 0xf33e5fb3c4
+		}
+		if c0&0x08 != 0 {
+			c ^= 0xae2eabe2a8
+		}
+		if c0&0x10 != 0 {
+			c ^= 0x1e4f43e470
+		}
+	}
+	return c ^ 1
+}
+
+// EncodeCashAddr encodes a 20 bytes hash with version byte, returns address with prefix.
+func EncodeCashAddr(prefix string, version byte, hash []byte) (string, error) {
+	if len(hash) != 20 {
+		return "", fmt.Errorf("cashaddr hash length %v not support", len(hash))
+	}
+	payload, err := bech32.ConvertBits(append([]byte{version}, hash...), 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	values := make([]byte, 0, len(prefix)+1+len(payload)+8)
+	for i := 0; i < len(prefix); i++ {
+		values = append(values, prefix[i]&0x1f)
+	}
+	values = append(values, 0)
+	values = append(values, payload...)
+	values = append(values, make([]byte, 8)...)
+	mod := cashAddrPolyMod(values)
+
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	sb.WriteString(":")
+	for _, d := range payload {
+		sb.WriteByte(cashAddrCharset[d])
+	}
+	for i := 0; i < 8; i++ {
+		sb.WriteByte(cashAddrCharset[(mod>>(5*(7-i)))&0x1f])
+	}
+	return sb.String(), nil
+}
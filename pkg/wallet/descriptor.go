@@ -0,0 +1,137 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+)
+
+// Descriptor is a BIP-380 output descriptor with checksum, Type is the address type of the descriptor.
+type Descriptor struct {
+	Type       string
+	Descriptor string
+}
+
+// DescriptorNetworkMap contains networks of tokens whose output descriptors are generated.
+var DescriptorNetworkMap = map[string]*Network{
+	BTC.Name: &BTCNetwork,
+	XTN.Name: &XTNNetwork,
+}
+
+const (
+	descriptorInputCharset    = "0123456789()[],'/*abcdefgh@:$%{}IJKLMNOPQRSTUVWXYZ&+-.;<=>?!^_|~ijklmnopqrstuvwxyzABCDEFGH`#\"\\ "
+	descriptorChecksumCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+var descriptorGenerator = [5]uint64{0xf5dee51989, 0xa9fdca3312, 0x1bab10e32d, 0x3706b1677a, 0x644d626ffd}
+
+// GenerateDescriptors returns pkh(), sh(wpkh()), wpkh() and tr() descriptors of the child key derived from root by path,
+// the key expression is the key origin [fingerprint/path] and extended public key of the network.
+func GenerateDescriptors(root crypto.CKDKey, path string, network *Network) ([]Descriptor, error) {
+	if root == nil || network == nil || network.Params == nil {
+		return nil, fmt.Errorf("root key or network is nil")
+	}
+	if root.GetType() != crypto.ECDSAKey {
+		return nil, fmt.Errorf("root key is not ecdsa key")
+	}
+	indexes, err := crypto.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint, err := crypto.Fingerprint(root)
+	if err != nil {
+		return nil, err
+	}
+	dk, err := crypto.DeriveIndexes(root, indexes)
+	if err != nil {
+		return nil, err
+	}
+	extendedKey, err := crypto.B58SerializeWithVersion(dk.PublicKey(), crypto.ExtendedKeyVersion{
+		Private: network.Params.HDPrivateKeyID,
+		Public:  network.Params.HDPublicKeyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	origin := hex.EncodeToString(fingerprint)
+	for _, index := range indexes {
+		if index >= hdkeychain.HardenedKeyStart {
+			origin += fmt.Sprintf("/%dh", index-hdkeychain.HardenedKeyStart)
+		} else {
+			origin += fmt.Sprintf("/%d", index)
+		}
+	}
+	keyExpression := "[" + origin + "]" + extendedKey
+
+	scripts := []Descriptor{{Type: LEGACY, Descriptor: "pkh(" + keyExpression + ")"}}
+	if network.SegWit {
+		scripts = append(scripts,
+			Descriptor{Type: NESTED_SEGWIT, Descriptor: "sh(wpkh(" + keyExpression + "))"},
+			Descriptor{Type: NATIVE_SEGWIT, Descriptor: "wpkh(" + keyExpression + ")"})
+	}
+	if network.Taproot {
+		scripts = append(scripts, Descriptor{Type: TAPROOT_BIP86, Descriptor: "tr(" + keyExpression + ")"})
+	}
+
+	descriptors := make([]Descriptor, 0, len(scripts))
+	for _, script := range scripts {
+		descriptor, err := AddDescriptorChecksum(script.Descriptor)
+		if err != nil {
+			return nil, err
+		}
+		descriptors = append(descriptors, Descriptor{Type: script.Type, Descriptor: descriptor})
+	}
+	return descriptors, nil
+}
+
+// AddDescriptorChecksum appends the BIP-380 checksum to the descriptor.
+func AddDescriptorChecksum(descriptor string) (string, error) {
+	symbols := make([]uint64, 0, len(descriptor)*2+8)
+	groups := make([]uint64, 0, 3)
+	for _, c := range descriptor {
+		v := strings.IndexRune(descriptorInputCharset, c)
+		if v < 0 {
+			return "", fmt.Errorf("descriptor character %q is invalid", c)
+		}
+		symbols = append(symbols, uint64(v&31))
+		groups = append(groups, uint64(v>>5))
+		if len(groups) == 3 {
+			symbols = append(symbols, groups[0]*9+groups[1]*3+groups[2])
+			groups = groups[:0]
+		}
+	}
+	switch len(groups) {
+	case 1:
+		symbols = append(symbols, groups[0])
+	case 2:
+		symbols = append(symbols, groups[0]*3+groups[1])
+	}
+	symbols = append(symbols, make([]uint64, 8)...)
+
+	checksum := descriptorPolymod(symbols) ^ 1
+	var sb strings.Builder
+	sb.WriteString(descriptor)
+	sb.WriteByte('#')
+	for i := 0; i < 8; i++ {
+		sb.WriteByte(descriptorChecksumCharset[(checksum>>(5*(7-i)))&31])
+	}
+	return sb.String(), nil
+}
+
+func descriptorPolymod(symbols []uint64) uint64 {
+	chk := uint64(1)
+	for _, value := range symbols {
+		top := chk >> 35
+		chk = (chk&0x7ffffffff)<<5 ^ value
+		for i, generator := range descriptorGenerator {
+			if (top>>i)&1 == 1 {
+				chk ^= generator
+			}
+		}
+	}
+	return chk
+}
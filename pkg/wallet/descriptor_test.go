@@ -0,0 +1,51 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddDescriptorChecksum(t *testing.T) {
+	// BIP-380 examples
+	descriptor, err := AddDescriptorChecksum("raw(deadbeef)")
+	assert.NoError(t, err)
+	assert.Equal(t, "raw(deadbeef)#89f8spxm", descriptor)
+	descriptor, err = AddDescriptorChecksum("addr(mkmZxiEcEd8ZqjQWVZuC6so5dFMKEFpN2j)")
+	assert.NoError(t, err)
+	assert.Equal(t, "addr(mkmZxiEcEd8ZqjQWVZuC6so5dFMKEFpN2j)#02wpgw69", descriptor)
+
+	_, err = AddDescriptorChecksum("raw(deadbeef)\n")
+	assert.Error(t, err)
+}
+
+func TestGenerateDescriptors(t *testing.T) {
+	// BIP-84 and BIP-86 test vectors of mnemonic "abandon ... about"
+	root, err := crypto.B58Deserialize("xprv9s21ZrQH143K3GJpoapnV8SFfukcVBSfeCficPSGfubmSFDxo1kuHnLisriDvSnRRuL2Qrg5ggqHKNVpxR86QEC8w35uxmGoggxtQTPvfUu")
+	assert.NoError(t, err)
+
+	descriptors, err := GenerateDescriptors(root, "m/84'/0'/0'", &BTCNetwork)
+	assert.NoError(t, err)
+	assert.Len(t, descriptors, 4)
+	assert.Equal(t, Descriptor{
+		Type: NATIVE_SEGWIT,
+		Descriptor: "wpkh([73c5da0a/84h/0h/0h]" +
+			"xpub6CatWdiZiodmUeTDp8LT5or8nmbKNcuyvz7WyksVFkKB4RHwCD3XyuvPEbvqAQY3rAPshWcMLoP2fMFMKHPJ4ZeZXYVUhLv1VMrjPC7PW6V)#tlegkln4",
+	}, descriptors[2])
+
+	descriptors, err = GenerateDescriptors(root, "m/86'/0'/0'", &BTCNetwork)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{LEGACY, NESTED_SEGWIT, NATIVE_SEGWIT, TAPROOT_BIP86},
+		[]string{descriptors[0].Type, descriptors[1].Type, descriptors[2].Type, descriptors[3].Type})
+	assert.Equal(t, "tr([73c5da0a/86h/0h/0h]"+
+		"xpub6BgBgsespWvERF3LHQu6CnqdvfEvtMcQjYrcRzx53QJjSxarj2afYWcLteoGVky7D3UKDP9QyrLprQ3VCECoY49yfdDEHGCtMMj92pReUsQ)#4elggcgx",
+		descriptors[3].Descriptor)
+
+	descriptors, err = GenerateDescriptors(root.PublicKey(), "m/84/1/0/0/1", &XTNNetwork)
+	assert.NoError(t, err)
+	assert.Contains(t, descriptors[0].Descriptor, "pkh([73c5da0a/84/1/0/0/1]tpub")
+
+	_, err = GenerateDescriptors(root, "m/x", &BTCNetwork)
+	assert.Error(t, err)
+}
@@ -0,0 +1,177 @@
+package wallet
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethCrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// EVMTransaction is the JSON description of an EVM transaction to sign.
+// Type 0 is legacy, 1 is EIP-2930 and 2 is EIP-1559; if type is omitted, EIP-1559 is used
+// when max fee fields are set, EIP-2930 when access list is set, otherwise legacy.
+// Amounts are decimal or 0x prefixed hex strings in wei.
+type EVMTransaction struct {
+	Type                 *uint8           `json:"type,omitempty"`
+	ChainID              string           `json:"chain_id"`
+	Nonce                uint64           `json:"nonce"`
+	GasLimit             uint64           `json:"gas_limit"`
+	GasPrice             string           `json:"gas_price,omitempty"`
+	MaxFeePerGas         string           `json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas string           `json:"max_priority_fee_per_gas,omitempty"`
+	To                   string           `json:"to,omitempty"`
+	Value                string           `json:"value,omitempty"`
+	Data                 string           `json:"data,omitempty"`
+	AccessList           types.AccessList `json:"access_list,omitempty"`
+}
+
+// SignEVMTransaction signs the transaction with the private key, legacy transaction is signed with EIP-155 replay protection.
+func SignEVMTransaction(key crypto.CKDKey, evmTx *EVMTransaction) (*types.Transaction, error) {
+	if key == nil || evmTx == nil {
+		return nil, fmt.Errorf("key or transaction is nil")
+	}
+	if key.GetType() != crypto.ECDSAKey || !key.IsPrivateKey() {
+		return nil, fmt.Errorf("key is not ecdsa private key")
+	}
+	privateKey, err := gethCrypto.ToECDSA(key.GetKey())
+	if err != nil {
+		return nil, err
+	}
+
+	chainID, err := parseEVMAmount("chain_id", evmTx.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	if chainID.Sign() <= 0 {
+		return nil, fmt.Errorf("chain_id is nil")
+	}
+	txData, err := evmTx.txData(chainID)
+	if err != nil {
+		return nil, err
+	}
+	return types.SignNewTx(privateKey, types.LatestSignerForChainID(chainID), txData)
+}
+
+func (evmTx *EVMTransaction) txData(chainID *big.Int) (types.TxData, error) {
+	var to *common.Address
+	if evmTx.To != "" {
+		if !common.IsHexAddress(evmTx.To) {
+			return nil, fmt.Errorf("to address %v is invalid", evmTx.To)
+		}
+		address := common.HexToAddress(evmTx.To)
+		to = &address
+	}
+	value, err := parseEVMAmount("value", evmTx.Value)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if evmTx.Data != "" {
+		if data, err = hexutil.Decode(evmTx.Data); err != nil {
+			return nil, fmt.Errorf("data is invalid: %v", err)
+		}
+	}
+	if to == nil && len(data) == 0 {
+		return nil, fmt.Errorf("to and data are both nil")
+	}
+
+	txType := evmTx.txType()
+	if txType == types.DynamicFeeTxType {
+		if evmTx.GasPrice != "" {
+			return nil, fmt.Errorf("gas_price is not allowed in eip-1559 transaction")
+		}
+		maxFee, err := parseEVMAmount("max_fee_per_gas", evmTx.MaxFeePerGas)
+		if err != nil {
+			return nil, err
+		}
+		maxPriorityFee, err := parseEVMAmount("max_priority_fee_per_gas", evmTx.MaxPriorityFeePerGas)
+		if err != nil {
+			return nil, err
+		}
+		if maxPriorityFee.Cmp(maxFee) > 0 {
+			return nil, fmt.Errorf("max_priority_fee_per_gas is greater than max_fee_per_gas")
+		}
+		return &types.DynamicFeeTx{
+			ChainID:    chainID,
+			Nonce:      evmTx.Nonce,
+			GasTipCap:  maxPriorityFee,
+			GasFeeCap:  maxFee,
+			Gas:        evmTx.GasLimit,
+			To:         to,
+			Value:      value,
+			Data:       data,
+			AccessList: evmTx.AccessList,
+		}, nil
+	}
+
+	if evmTx.MaxFeePerGas != "" || evmTx.MaxPriorityFeePerGas != "" {
+		return nil, fmt.Errorf("max fee is not allowed in transaction type %v", txType)
+	}
+	gasPrice, err := parseEVMAmount("gas_price", evmTx.GasPrice)
+	if err != nil {
+		return nil, err
+	}
+	switch txType {
+	case types.LegacyTxType:
+		if len(evmTx.AccessList) > 0 {
+			return nil, fmt.Errorf("access_list is not allowed in legacy transaction")
+		}
+		return &types.LegacyTx{
+			Nonce:    evmTx.Nonce,
+			GasPrice: gasPrice,
+			Gas:      evmTx.GasLimit,
+			To:       to,
+			Value:    value,
+			Data:     data,
+		}, nil
+	case types.AccessListTxType:
+		return &types.AccessListTx{
+			ChainID:    chainID,
+			Nonce:      evmTx.Nonce,
+			GasPrice:   gasPrice,
+			Gas:        evmTx.GasLimit,
+			To:         to,
+			Value:      value,
+			Data:       data,
+			AccessList: evmTx.AccessList,
+		}, nil
+	default:
+		return nil, fmt.Errorf("transaction type %v not support", txType)
+	}
+}
+
+func (evmTx *EVMTransaction) txType() uint8 {
+	switch {
+	case evmTx.Type != nil:
+		return *evmTx.Type
+	case evmTx.MaxFeePerGas != "" || evmTx.MaxPriorityFeePerGas != "":
+		return types.DynamicFeeTxType
+	case len(evmTx.AccessList) > 0:
+		return types.AccessListTxType
+	default:
+		return types.LegacyTxType
+	}
+}
+
+// parseEVMAmount parses decimal or 0x prefixed hex string, empty string is zero.
+func parseEVMAmount(name string, amount string) (*big.Int, error) {
+	if amount == "" {
+		return new(big.Int), nil
+	}
+	var n *big.Int
+	var ok bool
+	if strings.HasPrefix(amount, "0x") || strings.HasPrefix(amount, "0X") {
+		n, ok = new(big.Int).SetString(amount[2:], 16)
+	} else {
+		n, ok = new(big.Int).SetString(amount, 10)
+	}
+	if !ok || n.Sign() < 0 {
+		return nil, fmt.Errorf("%v %v is invalid", name, amount)
+	}
+	return n, nil
+}
@@ -0,0 +1,65 @@
+package wallet
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignEVMTransaction(t *testing.T) {
+	// EIP-155 example private key
+	d, _ := new(big.Int).SetString("4646464646464646464646464646464646464646464646464646464646464646", 16)
+	key := crypto.NewECDSAExtendedKey(crypto.CreateECDSAExtendedPrivateKey(crypto.CreateECDSAPrivateKey(crypto.S256(), d), make([]byte, 32)))
+	addresses, err := GenerateEVMAddress(key)
+	assert.NoError(t, err)
+
+	evmTx := &EVMTransaction{}
+	err = json.Unmarshal([]byte(`{"chain_id":"1","nonce":9,"gas_price":"20000000000","gas_limit":21000,
+		"to":"0x3535353535353535353535353535353535353535","value":"0xde0b6b3a7640000"}`), evmTx)
+	assert.NoError(t, err)
+	tx, err := SignEVMTransaction(key, evmTx)
+	assert.NoError(t, err)
+	raw, err := tx.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, "0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025"+
+		"a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83",
+		hexutil.Encode(raw))
+
+	evmTx = &EVMTransaction{}
+	err = json.Unmarshal([]byte(`{"chain_id":"5000","nonce":1,"gas_price":"20000000000","gas_limit":30000,
+		"to":"0x3535353535353535353535353535353535353535","data":"0x1234",
+		"access_list":[{"address":"0x3535353535353535353535353535353535353535","storageKeys":[]}]}`), evmTx)
+	assert.NoError(t, err)
+	tx, err = SignEVMTransaction(key, evmTx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(types.AccessListTxType), tx.Type())
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	assert.NoError(t, err)
+	assert.Equal(t, addresses[0].Address, sender.Hex())
+
+	evmTx = &EVMTransaction{}
+	err = json.Unmarshal([]byte(`{"chain_id":"1","nonce":0,"max_fee_per_gas":"30000000000",
+		"max_priority_fee_per_gas":"1000000000","gas_limit":21000,"to":"0x3535353535353535353535353535353535353535","value":"1"}`), evmTx)
+	assert.NoError(t, err)
+	tx, err = SignEVMTransaction(key, evmTx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+	assert.Equal(t, int64(1), tx.Value().Int64())
+	sender, err = types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	assert.NoError(t, err)
+	assert.Equal(t, addresses[0].Address, sender.Hex())
+
+	_, err = SignEVMTransaction(key, &EVMTransaction{ChainID: "1", GasPrice: "1", MaxFeePerGas: "1", To: evmTx.To})
+	assert.Error(t, err)
+	_, err = SignEVMTransaction(key, &EVMTransaction{ChainID: "1", To: "0x1234"})
+	assert.Error(t, err)
+	_, err = SignEVMTransaction(key, &EVMTransaction{To: evmTx.To})
+	assert.Error(t, err)
+	_, err = SignEVMTransaction(key.PublicKey(), evmTx)
+	assert.Error(t, err)
+}
@@ -0,0 +1,39 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	gethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+// EncryptEVMKeystore encrypts the private key in Web3 Secret Storage V3 JSON with scrypt KDF and AES-128-CTR,
+// scryptN and scryptP are scrypt parameters such as keystore.StandardScryptN and keystore.StandardScryptP,
+// returns the EVM address and keystore JSON.
+func EncryptEVMKeystore(key crypto.CKDKey, passphrase string, scryptN int, scryptP int) (Address, []byte, error) {
+	if key == nil {
+		return Address{}, nil, fmt.Errorf("key is nil")
+	}
+	if key.GetType() != crypto.ECDSAKey || !key.IsPrivateKey() {
+		return Address{}, nil, fmt.Errorf("key is not ecdsa private key")
+	}
+	if passphrase == "" {
+		return Address{}, nil, fmt.Errorf("passphrase is nil")
+	}
+	privateKey, err := gethCrypto.ToECDSA(key.GetKey())
+	if err != nil {
+		return Address{}, nil, err
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return Address{}, nil, err
+	}
+	address := gethCrypto.PubkeyToAddress(privateKey.PublicKey)
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{Id: id, Address: address, PrivateKey: privateKey}, passphrase, scryptN, scryptP)
+	if err != nil {
+		return Address{}, nil, err
+	}
+	return Address{Address: address.Hex()}, keyJSON, nil
+}
@@ -0,0 +1,49 @@
+package wallet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	gethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptEVMKeystore(t *testing.T) {
+	key := testPrivateKey(t, gethCrypto.Keccak256([]byte("cow")))
+	address, keyJSON, err := EncryptEVMKeystore(key, "testpassword", keystore.LightScryptN, keystore.LightScryptP)
+	assert.NoError(t, err)
+	assert.Equal(t, "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", address.Address)
+
+	encrypted := struct {
+		Version int `json:"version"`
+		Crypto  struct {
+			Cipher string `json:"cipher"`
+			KDF    string `json:"kdf"`
+		} `json:"crypto"`
+	}{}
+	assert.NoError(t, json.Unmarshal(keyJSON, &encrypted))
+	assert.Equal(t, 3, encrypted.Version)
+	assert.Equal(t, "aes-128-ctr", encrypted.Crypto.Cipher)
+	assert.Equal(t, "scrypt", encrypted.Crypto.KDF)
+
+	decrypted, err := keystore.DecryptKey(keyJSON, "testpassword")
+	assert.NoError(t, err)
+	assert.Equal(t, address.Address, decrypted.Address.Hex())
+	assert.Equal(t, key.GetKey(), gethCrypto.FromECDSA(decrypted.PrivateKey))
+	_, err = keystore.DecryptKey(keyJSON, "wrongpassword")
+	assert.Error(t, err)
+
+	_, _, err = EncryptEVMKeystore(key, "", keystore.LightScryptN, keystore.LightScryptP)
+	assert.Error(t, err)
+	_, _, err = EncryptEVMKeystore(key.PublicKey(), "testpassword", keystore.LightScryptN, keystore.LightScryptP)
+	assert.Error(t, err)
+}
+
+func TestTokenEVM(t *testing.T) {
+	for name, evm := range map[string]bool{"ETH": true, "MNT": true, "INJ": true, "EVMOS": true, "ATOM": false, "BTC": false, "SOL": false} {
+		token, err := GetToken(name)
+		assert.NoError(t, err)
+		assert.Equal(t, evm, token.EVM, name)
+	}
+}
@@ -0,0 +1,136 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/ethereum/go-ethereum/accounts"
+	gethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Message signature schemes.
+const (
+	EIP191 = "eip191"
+	EIP712 = "eip712"
+	BIP137 = "bip137"
+)
+
+// bip137HeaderOffset is the BIP-137 header byte offset of compressed key signature by address type.
+var bip137HeaderOffset = map[string]byte{
+	LEGACY:        31,
+	NESTED_SEGWIT: 35,
+	NATIVE_SEGWIT: 39,
+}
+
+// SignEIP191Message signs the message with EIP-191 personal_sign prefix,
+// returns the signing address and 65 bytes signature r || s || v, v is 27 or 28.
+func SignEIP191Message(key crypto.CKDKey, message []byte) (Address, []byte, error) {
+	return signEVMHash(key, accounts.TextHash(message))
+}
+
+// SignEIP712TypedData signs the EIP-712 typed data JSON,
+// returns the signing address and 65 bytes signature r || s || v, v is 27 or 28.
+func SignEIP712TypedData(key crypto.CKDKey, typedDataJSON []byte) (Address, []byte, error) {
+	typedData := apitypes.TypedData{}
+	if err := json.Unmarshal(typedDataJSON, &typedData); err != nil {
+		return Address{}, nil, fmt.Errorf("parse typed data failed: %v", err)
+	}
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return Address{}, nil, fmt.Errorf("hash typed data failed: %v", err)
+	}
+	return signEVMHash(key, hash)
+}
+
+func signEVMHash(key crypto.CKDKey, hash []byte) (Address, []byte, error) {
+	if key == nil {
+		return Address{}, nil, fmt.Errorf("key is nil")
+	}
+	if key.GetType() != crypto.ECDSAKey || !key.IsPrivateKey() {
+		return Address{}, nil, fmt.Errorf("key is not ecdsa private key")
+	}
+	addresses, err := GenerateEVMAddress(key)
+	if err != nil {
+		return Address{}, nil, err
+	}
+	privateKey, err := gethCrypto.ToECDSA(key.GetKey())
+	if err != nil {
+		return Address{}, nil, err
+	}
+	signature, err := gethCrypto.Sign(hash, privateKey)
+	if err != nil {
+		return Address{}, nil, err
+	}
+	signature[gethCrypto.RecoveryIDOffset] += 27
+	return addresses[0], signature, nil
+}
+
+// SignBIP137Message signs the message with the network signed message prefix,
+// addressType is LEGACY, NESTED_SEGWIT or NATIVE_SEGWIT,
+// returns the signing address and base64 encoded 65 bytes signature with BIP-137 header.
+func SignBIP137Message(key crypto.CKDKey, network *Network, addressType string, message []byte) (Address, string, error) {
+	if key == nil || network == nil {
+		return Address{}, "", fmt.Errorf("key or network is nil")
+	}
+	if key.GetType() != crypto.ECDSAKey || !key.IsPrivateKey() {
+		return Address{}, "", fmt.Errorf("key is not ecdsa private key")
+	}
+	headerOffset, exists := bip137HeaderOffset[addressType]
+	if !exists {
+		return Address{}, "", fmt.Errorf("address type %v not support", addressType)
+	}
+	addresses, err := PubKeyToNetworkAddr(key, network)
+	if err != nil {
+		return Address{}, "", err
+	}
+	var address *Address
+	for i := range addresses {
+		if addresses[i].Type == addressType {
+			address = &addresses[i]
+		}
+	}
+	if address == nil {
+		return Address{}, "", fmt.Errorf("address type %v not support by network %v", addressType, network.Params.Name)
+	}
+
+	hash, err := bitcoinMessageHash(network, message)
+	if err != nil {
+		return Address{}, "", err
+	}
+	privKey, _ := btcec.PrivKeyFromBytes(key.GetKey())
+	signature := ecdsa.SignCompact(privKey, hash, true)
+	// compact signature header is 31 + recovery id for compressed key
+	signature[0] = signature[0] - 31 + headerOffset
+	return *address, base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// bitcoinMessageHash is double sha256 of the var strings of signed message prefix and message.
+func bitcoinMessageHash(network *Network, message []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := wire.WriteVarString(&buf, 0, messageMagic(network)); err != nil {
+		return nil, err
+	}
+	if err := wire.WriteVarBytes(&buf, 0, message); err != nil {
+		return nil, err
+	}
+	return chainhash.DoubleHashB(buf.Bytes()), nil
+}
+
+func messageMagic(network *Network) string {
+	switch network.Params.Name {
+	case LitecoinMainNetParams.Name:
+		return "Litecoin Signed Message:\n"
+	case DogecoinMainNetParams.Name:
+		return "Dogecoin Signed Message:\n"
+	default:
+		return "Bitcoin Signed Message:\n"
+	}
+}
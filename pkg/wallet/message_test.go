@@ -0,0 +1,110 @@
+package wallet
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/utils"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/ethereum/go-ethereum/accounts"
+	gethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func testPrivateKey(t *testing.T, d []byte) crypto.CKDKey {
+	t.Helper()
+	privateKey := crypto.CreateECDSAPrivateKey(crypto.S256(), new(big.Int).SetBytes(d))
+	return crypto.NewECDSAExtendedKey(crypto.CreateECDSAExtendedPrivateKey(privateKey, make([]byte, 32)))
+}
+
+func TestSignEIP191Message(t *testing.T) {
+	key := testPrivateKey(t, gethCrypto.Keccak256([]byte("cow")))
+	message := []byte("cobo mpc recovery")
+	address, signature, err := SignEIP191Message(key, message)
+	assert.NoError(t, err)
+	assert.Equal(t, "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", address.Address)
+	assert.Len(t, signature, 65)
+	assert.Contains(t, []byte{27, 28}, signature[64])
+
+	signature[64] -= 27
+	pubKey, err := gethCrypto.SigToPub(accounts.TextHash(message), signature)
+	assert.NoError(t, err)
+	assert.Equal(t, address.Address, gethCrypto.PubkeyToAddress(*pubKey).Hex())
+
+	_, _, err = SignEIP191Message(key.PublicKey(), message)
+	assert.Error(t, err)
+}
+
+func TestSignEIP712TypedData(t *testing.T) {
+	// EIP-712 specification example
+	typedData := `{
+		"types": {
+			"EIP712Domain": [
+				{"name": "name", "type": "string"},
+				{"name": "version", "type": "string"},
+				{"name": "chainId", "type": "uint256"},
+				{"name": "verifyingContract", "type": "address"}
+			],
+			"Person": [
+				{"name": "name", "type": "string"},
+				{"name": "wallet", "type": "address"}
+			],
+			"Mail": [
+				{"name": "from", "type": "Person"},
+				{"name": "to", "type": "Person"},
+				{"name": "contents", "type": "string"}
+			]
+		},
+		"primaryType": "Mail",
+		"domain": {
+			"name": "Ether Mail",
+			"version": "1",
+			"chainId": 1,
+			"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+		},
+		"message": {
+			"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+			"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+			"contents": "Hello, Bob!"
+		}
+	}`
+	key := testPrivateKey(t, gethCrypto.Keccak256([]byte("cow")))
+	address, signature, err := SignEIP712TypedData(key, []byte(typedData))
+	assert.NoError(t, err)
+	assert.Equal(t, "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", address.Address)
+	assert.Equal(t, "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"+
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b915621c",
+		utils.Encode(signature))
+
+	_, _, err = SignEIP712TypedData(key, []byte(`{"types":{}}`))
+	assert.Error(t, err)
+}
+
+func TestSignBIP137Message(t *testing.T) {
+	// bitcoinjs-message example
+	wif, err := btcutil.DecodeWIF("L4rK1yDtCWekvXuE6oXD9jCYfFNV2cWRpVuPLBcCU2z8TrisoyY1")
+	assert.NoError(t, err)
+	key := testPrivateKey(t, wif.PrivKey.Serialize())
+	message := []byte("This is an example of a signed message.")
+
+	address, signature, err := SignBIP137Message(key, &BTCNetwork, LEGACY, message)
+	assert.NoError(t, err)
+	assert.Equal(t, "1F3sAm6ZtwLAUnj7d38pGFxtP3RVEvtsbV", address.Address)
+	assert.Equal(t, "H9L5yLFjti0QTHhPyFrZCT1V/MMnBtXKmoiKDZ78NDBjERki6ZTQZdSMCtkgoNmp17By9ItJr8o7ChX0XxY91nk=", signature)
+
+	address, signature, err = SignBIP137Message(key, &BTCNetwork, NESTED_SEGWIT, message)
+	assert.NoError(t, err)
+	assert.Equal(t, NESTED_SEGWIT, address.Type)
+	assert.Equal(t, "I9L5yLFjti0QTHhPyFrZCT1V/MMnBtXKmoiKDZ78NDBjERki6ZTQZdSMCtkgoNmp17By9ItJr8o7ChX0XxY91nk=", signature)
+
+	address, signature, err = SignBIP137Message(key, &BTCNetwork, NATIVE_SEGWIT, message)
+	assert.NoError(t, err)
+	assert.Equal(t, NATIVE_SEGWIT, address.Type)
+	assert.Equal(t, "J9L5yLFjti0QTHhPyFrZCT1V/MMnBtXKmoiKDZ78NDBjERki6ZTQZdSMCtkgoNmp17By9ItJr8o7ChX0XxY91nk=", signature)
+
+	_, _, err = SignBIP137Message(key, &BTCNetwork, TAPROOT_BIP86, message)
+	assert.Error(t, err)
+	_, _, err = SignBIP137Message(key, &DOGENetwork, NATIVE_SEGWIT, message)
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// Network defines the address parameters of a bitcoin-like chain
+// and the address types the chain supports.
+type Network struct {
+	Params         *chaincfg.Params
+	SegWit         bool
+	Taproot        bool
+	CashAddrPrefix string
+}
+
+var (
+	LitecoinMainNetParams = chaincfg.Params{
+		Name:             "litecoin",
+		PubKeyHashAddrID: 0x30, // starts with L
+		ScriptHashAddrID: 0x32, // starts with M
+		PrivateKeyID:     0xb0,
+		Bech32HRPSegwit:  "ltc",
+		HDPrivateKeyID:   [4]byte{0x04, 0x88, 0xad, 0xe4},
+		HDPublicKeyID:    [4]byte{0x04, 0x88, 0xb2, 0x1e},
+		HDCoinType:       2,
+	}
+
+	DogecoinMainNetParams = chaincfg.Params{
+		Name:             "dogecoin",
+		PubKeyHashAddrID: 0x1e, // starts with D
+		ScriptHashAddrID: 0x16, // starts with 9 or A
+		PrivateKeyID:     0x9e,
+		HDPrivateKeyID:   [4]byte{0x02, 0xfa, 0xc3, 0x98},
+		HDPublicKeyID:    [4]byte{0x02, 0xfa, 0xca, 0xfd},
+		HDCoinType:       3,
+	}
+
+	BitcoinCashMainNetParams = chaincfg.Params{
+		Name:             "bitcoincash",
+		PubKeyHashAddrID: 0x00, // starts with 1
+		ScriptHashAddrID: 0x05, // starts with 3
+		PrivateKeyID:     0x80,
+		HDPrivateKeyID:   [4]byte{0x04, 0x88, 0xad, 0xe4},
+		HDPublicKeyID:    [4]byte{0x04, 0x88, 0xb2, 0x1e},
+		HDCoinType:       145,
+	}
+)
+
+var (
+	BTCNetwork = Network{
+		Params:  &chaincfg.MainNetParams,
+		SegWit:  true,
+		Taproot: true,
+	}
+
+	XTNNetwork = Network{
+		Params:  &chaincfg.TestNet3Params,
+		SegWit:  true,
+		Taproot: true,
+	}
+
+	LTCNetwork = Network{
+		Params:  &LitecoinMainNetParams,
+		SegWit:  true,
+		Taproot: true,
+	}
+
+	DOGENetwork = Network{
+		Params: &DogecoinMainNetParams,
+	}
+
+	BCHNetwork = Network{
+		Params:         &BitcoinCashMainNetParams,
+		CashAddrPrefix: "bitcoincash",
+	}
+)
+
+var NetworkMap = map[string]*Network{
+	"BTC":  &BTCNetwork,
+	"XTN":  &XTNNetwork,
+	"LTC":  &LTCNetwork,
+	"DOGE": &DOGENetwork,
+	"BCH":  &BCHNetwork,
+}
+
+func GetNetwork(name string) (*Network, error) {
+	if network, exists := NetworkMap[name]; exists {
+		return network, nil
+	}
+	return nil, fmt.Errorf("network %v not support", name)
+}
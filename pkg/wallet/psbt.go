@@ -0,0 +1,208 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// SignPSBT signs the inputs of packet whose BIP32 derivations belong to the root private key.
+// P2PKH, P2WPKH, P2SH-P2WPKH and P2TR key path (BIP86 tweaked or untweaked) inputs are supported,
+// returns indexes of the signed inputs.
+//
+//nolint:gocognit
+func SignPSBT(packet *psbt.Packet, root crypto.CKDKey) ([]int, error) {
+	if packet == nil || root == nil {
+		return nil, fmt.Errorf("packet or root key is nil")
+	}
+	if root.GetType() != crypto.ECDSAKey || !root.IsPrivateKey() {
+		return nil, fmt.Errorf("root key is not ecdsa private key")
+	}
+	fingerprint, err := crypto.Fingerprint(root)
+	if err != nil {
+		return nil, err
+	}
+	masterFingerprint := binary.LittleEndian.Uint32(fingerprint)
+
+	prevOutFetcher, err := psbtPrevOutFetcher(packet)
+	if err != nil {
+		return nil, err
+	}
+	tx := packet.UnsignedTx
+	sigHashes := txscript.NewTxSigHashes(tx, prevOutFetcher)
+	updater, err := psbt.NewUpdater(packet)
+	if err != nil {
+		return nil, err
+	}
+
+	signed := make([]int, 0)
+	for i := range packet.Inputs {
+		pIn := &packet.Inputs[i]
+		privKey, found, err := psbtInputKey(root, masterFingerprint, pIn)
+		if err != nil {
+			return signed, fmt.Errorf("input %v: %v", i, err)
+		}
+		if !found {
+			continue
+		}
+
+		prevOut := prevOutFetcher.FetchPrevOutput(tx.TxIn[i].PreviousOutPoint)
+		pubKey := privKey.PubKey()
+		pubBytes := pubKey.SerializeCompressed()
+		p2wpkh, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(btcutil.Hash160(pubBytes)).Script()
+		if err != nil {
+			return signed, err
+		}
+
+		hashType := pIn.SighashType
+		switch txscript.GetScriptClass(prevOut.PkScript) {
+		case txscript.PubKeyHashTy:
+			if pIn.NonWitnessUtxo == nil {
+				return signed, fmt.Errorf("input %v: p2pkh input requires non witness utxo", i)
+			}
+			if !bytes.Equal(prevOut.PkScript[3:23], btcutil.Hash160(pubBytes)) {
+				return signed, fmt.Errorf("input %v: public key hash mismatch", i)
+			}
+			if hashType == 0 {
+				hashType = txscript.SigHashAll
+			}
+			sig, err := txscript.RawTxInSignature(tx, i, prevOut.PkScript, hashType, privKey)
+			if err != nil {
+				return signed, err
+			}
+			if _, err := updater.Sign(i, sig, pubBytes, nil, nil); err != nil {
+				return signed, fmt.Errorf("input %v: %v", i, err)
+			}
+		case txscript.WitnessV0PubKeyHashTy:
+			if !bytes.Equal(prevOut.PkScript, p2wpkh) {
+				return signed, fmt.Errorf("input %v: witness public key hash mismatch", i)
+			}
+			if hashType == 0 {
+				hashType = txscript.SigHashAll
+			}
+			sig, err := txscript.RawTxInWitnessSignature(tx, sigHashes, i, prevOut.Value, prevOut.PkScript, hashType, privKey)
+			if err != nil {
+				return signed, err
+			}
+			if _, err := updater.Sign(i, sig, pubBytes, nil, nil); err != nil {
+				return signed, fmt.Errorf("input %v: %v", i, err)
+			}
+		case txscript.ScriptHashTy:
+			if !bytes.Equal(prevOut.PkScript[2:22], btcutil.Hash160(p2wpkh)) {
+				return signed, fmt.Errorf("input %v: redeem script hash mismatch, only p2sh-p2wpkh supported", i)
+			}
+			if hashType == 0 {
+				hashType = txscript.SigHashAll
+			}
+			sig, err := txscript.RawTxInWitnessSignature(tx, sigHashes, i, prevOut.Value, p2wpkh, hashType, privKey)
+			if err != nil {
+				return signed, err
+			}
+			if _, err := updater.Sign(i, sig, pubBytes, p2wpkh, nil); err != nil {
+				return signed, fmt.Errorf("input %v: %v", i, err)
+			}
+		case txscript.WitnessV1TaprootTy:
+			sig, err := taprootKeySpendSignature(tx, sigHashes, prevOutFetcher, i, prevOut, hashType, privKey)
+			if err != nil {
+				return signed, fmt.Errorf("input %v: %v", i, err)
+			}
+			pIn.TaprootKeySpendSig = sig
+		default:
+			return signed, fmt.Errorf("input %v: script type %v not support", i, txscript.GetScriptClass(prevOut.PkScript))
+		}
+		signed = append(signed, i)
+	}
+	return signed, nil
+}
+
+// taprootKeySpendSignature signs the taproot input with the untweaked internal key
+// or the BIP86 tweaked key, depending on which one the output key is.
+func taprootKeySpendSignature(tx *wire.MsgTx, sigHashes *txscript.TxSigHashes, prevOutFetcher txscript.PrevOutputFetcher,
+	idx int, prevOut *wire.TxOut, hashType txscript.SigHashType, privKey *btcec.PrivateKey,
+) ([]byte, error) {
+	outputKey := prevOut.PkScript[2:]
+	pubKey := privKey.PubKey()
+	switch {
+	case bytes.Equal(outputKey, schnorr.SerializePubKey(pubKey)):
+		sigHash, err := txscript.CalcTaprootSignatureHash(sigHashes, hashType, tx, idx, prevOutFetcher)
+		if err != nil {
+			return nil, err
+		}
+		signature, err := schnorr.Sign(privKey, sigHash)
+		if err != nil {
+			return nil, err
+		}
+		sig := signature.Serialize()
+		if hashType != txscript.SigHashDefault {
+			sig = append(sig, byte(hashType))
+		}
+		return sig, nil
+	case bytes.Equal(outputKey, schnorr.SerializePubKey(txscript.ComputeTaprootKeyNoScript(pubKey))):
+		return txscript.RawTxInTaprootSignature(tx, sigHashes, idx, prevOut.Value, prevOut.PkScript, []byte{}, hashType, privKey)
+	default:
+		return nil, fmt.Errorf("taproot output key mismatch")
+	}
+}
+
+// psbtInputKey derives the private key of the input from its BIP32 derivations matching the master fingerprint,
+// found is false if the input does not belong to the root key.
+func psbtInputKey(root crypto.CKDKey, masterFingerprint uint32, pIn *psbt.PInput) (*btcec.PrivateKey, bool, error) {
+	for _, derivation := range pIn.Bip32Derivation {
+		if derivation.MasterKeyFingerprint != masterFingerprint {
+			continue
+		}
+		dk, err := crypto.DeriveIndexes(root, derivation.Bip32Path)
+		if err != nil {
+			return nil, false, err
+		}
+		if bytes.Equal(dk.PublicKey().GetKey(), derivation.PubKey) {
+			privKey, _ := btcec.PrivKeyFromBytes(dk.GetKey())
+			return privKey, true, nil
+		}
+	}
+	for _, derivation := range pIn.TaprootBip32Derivation {
+		if derivation.MasterKeyFingerprint != masterFingerprint {
+			continue
+		}
+		dk, err := crypto.DeriveIndexes(root, derivation.Bip32Path)
+		if err != nil {
+			return nil, false, err
+		}
+		if bytes.Equal(dk.PublicKey().GetKey()[1:], derivation.XOnlyPubKey) {
+			privKey, _ := btcec.PrivKeyFromBytes(dk.GetKey())
+			return privKey, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// psbtPrevOutFetcher collects the spent outputs of all inputs, which taproot signature hash commits to.
+func psbtPrevOutFetcher(packet *psbt.Packet) (*txscript.MultiPrevOutFetcher, error) {
+	if packet.UnsignedTx == nil || len(packet.Inputs) != len(packet.UnsignedTx.TxIn) {
+		return nil, fmt.Errorf("packet inputs mismatch unsigned transaction")
+	}
+	prevOuts := make(map[wire.OutPoint]*wire.TxOut, len(packet.Inputs))
+	for i, pIn := range packet.Inputs {
+		outPoint := packet.UnsignedTx.TxIn[i].PreviousOutPoint
+		switch {
+		case pIn.WitnessUtxo != nil:
+			prevOuts[outPoint] = pIn.WitnessUtxo
+		case pIn.NonWitnessUtxo != nil:
+			if pIn.NonWitnessUtxo.TxHash() != outPoint.Hash || int(outPoint.Index) >= len(pIn.NonWitnessUtxo.TxOut) {
+				return nil, fmt.Errorf("input %v non witness utxo mismatch previous outpoint", i)
+			}
+			prevOuts[outPoint] = pIn.NonWitnessUtxo.TxOut[outPoint.Index]
+		default:
+			return nil, fmt.Errorf("input %v utxo is nil", i)
+		}
+	}
+	return txscript.NewMultiPrevOutFetcher(prevOuts), nil
+}
@@ -0,0 +1,123 @@
+package wallet
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignPSBT(t *testing.T) {
+	root, err := crypto.B58Deserialize("xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi")
+	assert.NoError(t, err)
+	fingerprint, err := crypto.Fingerprint(root)
+	assert.NoError(t, err)
+	masterFingerprint := binary.LittleEndian.Uint32(fingerprint)
+
+	paths := [][]uint32{
+		{44 + 1<<31, 1 << 31, 1 << 31, 0, 0},
+		{84 + 1<<31, 1 << 31, 1 << 31, 0, 0},
+		{49 + 1<<31, 1 << 31, 1 << 31, 0, 0},
+		{86 + 1<<31, 1 << 31, 1 << 31, 0, 0},
+		{86 + 1<<31, 1 << 31, 1 << 31, 0, 1},
+	}
+	addressTypes := []string{LEGACY, NATIVE_SEGWIT, NESTED_SEGWIT, TAPROOT_BIP86, TAPROOT}
+
+	// funding transaction pays to one address of each type
+	fundingTx := wire.NewMsgTx(2)
+	fundingTx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: chainhash.Hash{1}}, nil, nil))
+	pubKeys := make([][]byte, 0, len(paths))
+	for i, path := range paths {
+		dk, err := crypto.DeriveIndexes(root, path)
+		assert.NoError(t, err)
+		pubKeys = append(pubKeys, dk.PublicKey().GetKey())
+		addresses, err := GenerateBTCAddresses(dk)
+		assert.NoError(t, err)
+		var pkScript []byte
+		for _, address := range addresses {
+			if address.Type != addressTypes[i] {
+				continue
+			}
+			addr, err := btcutil.DecodeAddress(address.Address, &chaincfg.MainNetParams)
+			assert.NoError(t, err)
+			pkScript, err = txscript.PayToAddrScript(addr)
+			assert.NoError(t, err)
+		}
+		fundingTx.AddTxOut(wire.NewTxOut(int64(100000*(i+1)), pkScript))
+	}
+
+	tx := wire.NewMsgTx(2)
+	for i := range paths {
+		tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: fundingTx.TxHash(), Index: uint32(i)}, nil, nil))
+	}
+	tx.AddTxOut(wire.NewTxOut(1400000, fundingTx.TxOut[1].PkScript))
+	packet, err := psbt.NewFromUnsignedTx(tx)
+	assert.NoError(t, err)
+	for i, path := range paths {
+		if i == 0 {
+			packet.Inputs[i].NonWitnessUtxo = fundingTx
+		} else {
+			packet.Inputs[i].WitnessUtxo = fundingTx.TxOut[i]
+		}
+		if addressTypes[i] == TAPROOT || addressTypes[i] == TAPROOT_BIP86 {
+			packet.Inputs[i].TaprootBip32Derivation = []*psbt.TaprootBip32Derivation{{
+				XOnlyPubKey:          pubKeys[i][1:],
+				MasterKeyFingerprint: masterFingerprint,
+				Bip32Path:            path,
+			}}
+		} else {
+			packet.Inputs[i].Bip32Derivation = []*psbt.Bip32Derivation{{
+				PubKey:               pubKeys[i],
+				MasterKeyFingerprint: masterFingerprint,
+				Bip32Path:            path,
+			}}
+		}
+	}
+
+	signed, err := SignPSBT(packet, root)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, signed)
+
+	assert.NoError(t, psbt.MaybeFinalizeAll(packet))
+	signedTx, err := psbt.Extract(packet)
+	assert.NoError(t, err)
+
+	prevOutFetcher := txscript.NewMultiPrevOutFetcher(nil)
+	for i, txOut := range fundingTx.TxOut {
+		prevOutFetcher.AddPrevOut(wire.OutPoint{Hash: fundingTx.TxHash(), Index: uint32(i)}, txOut)
+	}
+	sigHashes := txscript.NewTxSigHashes(signedTx, prevOutFetcher)
+	for i := range signedTx.TxIn {
+		prevOut := fundingTx.TxOut[i]
+		vm, err := txscript.NewEngine(prevOut.PkScript, signedTx, i, txscript.StandardVerifyFlags, nil, sigHashes,
+			prevOut.Value, prevOutFetcher)
+		assert.NoError(t, err)
+		assert.NoError(t, vm.Execute(), "input %v", i)
+	}
+
+	// inputs of other master key are skipped
+	other, err := crypto.B58Deserialize(
+		"xprv9s21ZrQH143K31xYSDQpPDxsXRTUcvj2iNHm5NUtrGiGG5e2DtALGdso3pGz6ssrdK4PFmM8NSpSBHNqPqm55Qn3LqFtT2emdEXVYsCzC2U")
+	assert.NoError(t, err)
+	packet, err = psbt.NewFromUnsignedTx(tx)
+	assert.NoError(t, err)
+	packet.Inputs[1].WitnessUtxo = fundingTx.TxOut[1]
+	packet.Inputs[1].Bip32Derivation = []*psbt.Bip32Derivation{{
+		PubKey: pubKeys[1], MasterKeyFingerprint: masterFingerprint, Bip32Path: paths[1],
+	}}
+	for i := range packet.Inputs {
+		if packet.Inputs[i].WitnessUtxo == nil {
+			packet.Inputs[i].WitnessUtxo = fundingTx.TxOut[i]
+		}
+	}
+	signed, err = SignPSBT(packet, other)
+	assert.NoError(t, err)
+	assert.Empty(t, signed)
+}
@@ -0,0 +1,208 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/btcsuite/btcd/chaincfg"
+	"gopkg.in/yaml.v3"
+)
+
+// Address families of tokens defined in token registry file.
+const (
+	FamilyBitcoin  = "bitcoin"
+	FamilyEVM      = "evm"
+	FamilyCosmos   = "cosmos"
+	FamilyTron     = "tron"
+	FamilyXRP      = "xrp"
+	FamilySolana   = "solana"
+	FamilyAptos    = "aptos"
+	FamilySui      = "sui"
+	FamilyStellar  = "stellar"
+	FamilyAlgorand = "algorand"
+	FamilyNear     = "near"
+)
+
+var familyCurve = map[string]crypto.CurveType{
+	FamilyBitcoin:  crypto.SECP256K1,
+	FamilyEVM:      crypto.SECP256K1,
+	FamilyCosmos:   crypto.SECP256K1,
+	FamilyTron:     crypto.SECP256K1,
+	FamilyXRP:      crypto.SECP256K1,
+	FamilySolana:   crypto.ED25519,
+	FamilyAptos:    crypto.ED25519,
+	FamilySui:      crypto.ED25519,
+	FamilyStellar:  crypto.ED25519,
+	FamilyAlgorand: crypto.ED25519,
+	FamilyNear:     crypto.ED25519,
+}
+
+var familyGenerator = map[string]func(key crypto.CKDKey) ([]Address, error){
+	FamilyEVM:      GenerateEVMAddress,
+	FamilyTron:     GenerateTRXAddress,
+	FamilyXRP:      GenerateXRPAddress,
+	FamilySolana:   GenerateSOLAddress,
+	FamilyAptos:    GenerateAPTAddress,
+	FamilySui:      GenerateSUIAddress,
+	FamilyStellar:  GenerateXLMAddress,
+	FamilyAlgorand: GenerateALGOAddress,
+	FamilyNear:     GenerateNEARAddress,
+}
+
+// NetworkConfig defines custom address parameters of bitcoin family token.
+type NetworkConfig struct {
+	PubKeyHashAddrID byte   `json:"pub_key_hash_addr_id" yaml:"pub_key_hash_addr_id"`
+	ScriptHashAddrID byte   `json:"script_hash_addr_id" yaml:"script_hash_addr_id"`
+	PrivateKeyID     byte   `json:"private_key_id" yaml:"private_key_id"`
+	Bech32HRPSegwit  string `json:"bech32_hrp_segwit,omitempty" yaml:"bech32_hrp_segwit,omitempty"`
+	SegWit           bool   `json:"segwit,omitempty" yaml:"segwit,omitempty"`
+	Taproot          bool   `json:"taproot,omitempty" yaml:"taproot,omitempty"`
+	CashAddrPrefix   string `json:"cashaddr_prefix,omitempty" yaml:"cashaddr_prefix,omitempty"`
+}
+
+// TokenConfig is a token registry file entry.
+// Network refers to a network in NetworkMap, Params defines a custom network, both only for bitcoin family.
+// HRP and Ethermint are only for cosmos family.
+type TokenConfig struct {
+	Name      string         `json:"name" yaml:"name"`
+	Family    string         `json:"family" yaml:"family"`
+	Curve     string         `json:"curve" yaml:"curve"`
+	Network   string         `json:"network,omitempty" yaml:"network,omitempty"`
+	Params    *NetworkConfig `json:"params,omitempty" yaml:"params,omitempty"`
+	HRP       string         `json:"hrp,omitempty" yaml:"hrp,omitempty"`
+	Ethermint bool           `json:"ethermint,omitempty" yaml:"ethermint,omitempty"`
+}
+
+// TokenRegistry is the token registry file, JSON or YAML format.
+type TokenRegistry struct {
+	Tokens []*TokenConfig `json:"tokens" yaml:"tokens"`
+}
+
+// RegistryTokenMap contains tokens loaded from token registry file.
+var RegistryTokenMap = map[string]Token{}
+
+// LoadTokenRegistry parses token registry file and registers its tokens,
+// file with .json extension is parsed as JSON, otherwise YAML.
+func LoadTokenRegistry(file string) error {
+	data, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return fmt.Errorf("read token registry file %v failed: %v", file, err)
+	}
+
+	registry := TokenRegistry{}
+	if strings.EqualFold(filepath.Ext(file), ".json") {
+		err = json.Unmarshal(data, &registry)
+	} else {
+		err = yaml.Unmarshal(data, &registry)
+	}
+	if err != nil {
+		return fmt.Errorf("parse token registry file %v failed: %v", file, err)
+	}
+
+	tokens := make(map[string]Token, len(registry.Tokens))
+	for _, config := range registry.Tokens {
+		token, err := config.Token()
+		if err != nil {
+			return err
+		}
+		if _, exists := tokens[token.Name]; exists {
+			return fmt.Errorf("token %v duplicated in token registry", token.Name)
+		}
+		tokens[token.Name] = token
+	}
+	// cosmos family tokens are registered as Cosmos SDK chains, same as built-in chains
+	for _, config := range registry.Tokens {
+		if config.Family == FamilyCosmos {
+			RegisterCosmosChain(config.Name, config.cosmosChain())
+		} else {
+			RegistryTokenMap[config.Name] = tokens[config.Name]
+		}
+	}
+	return nil
+}
+
+// Token validates token config and creates the token.
+func (config *TokenConfig) Token() (Token, error) {
+	if config == nil || config.Name == "" {
+		return Token{}, fmt.Errorf("token name is nil")
+	}
+	if _, exists := TokenMap[config.Name]; exists {
+		return Token{}, fmt.Errorf("token %v is already supported", config.Name)
+	}
+	if _, exists := CosmosChainMap[config.Name]; exists {
+		return Token{}, fmt.Errorf("token %v is already supported", config.Name)
+	}
+
+	curve, exists := familyCurve[config.Family]
+	if !exists {
+		return Token{}, fmt.Errorf("token %v address family %v not support", config.Name, config.Family)
+	}
+	if crypto.CurveNameType[config.Curve] != curve {
+		return Token{}, fmt.Errorf("token %v curve %v mismatch address family %v", config.Name, config.Curve, config.Family)
+	}
+
+	switch config.Family {
+	case FamilyBitcoin:
+		network, err := config.network()
+		if err != nil {
+			return Token{}, err
+		}
+		return Token{
+			Name: config.Name,
+			GenerateAddresses: func(key crypto.CKDKey) ([]Address, error) {
+				return PubKeyToNetworkAddr(key, network)
+			},
+		}, nil
+	case FamilyCosmos:
+		if config.HRP == "" {
+			return Token{}, fmt.Errorf("token %v hrp is nil", config.Name)
+		}
+		return NewCosmosToken(config.Name, config.cosmosChain()), nil
+	default:
+		return Token{
+			Name:              config.Name,
+			GenerateAddresses: familyGenerator[config.Family],
+			EVM:               config.Family == FamilyEVM,
+		}, nil
+	}
+}
+
+func (config *TokenConfig) cosmosChain() CosmosChain {
+	return CosmosChain{HRP: config.HRP, Ethermint: config.Ethermint}
+}
+
+func (config *TokenConfig) network() (*Network, error) {
+	if config.Network != "" && config.Params != nil {
+		return nil, fmt.Errorf("token %v network and params at same time is not allowed", config.Name)
+	}
+	if config.Network != "" {
+		return GetNetwork(config.Network)
+	}
+	if config.Params == nil {
+		return nil, fmt.Errorf("token %v network or params is nil", config.Name)
+	}
+
+	params := config.Params
+	if params.SegWit && params.Bech32HRPSegwit == "" {
+		return nil, fmt.Errorf("token %v segwit enabled without bech32 hrp", config.Name)
+	}
+	if params.Taproot && !params.SegWit {
+		return nil, fmt.Errorf("token %v taproot enabled without segwit", config.Name)
+	}
+	return &Network{
+		Params: &chaincfg.Params{
+			Name:             strings.ToLower(config.Name),
+			PubKeyHashAddrID: params.PubKeyHashAddrID,
+			ScriptHashAddrID: params.ScriptHashAddrID,
+			PrivateKeyID:     params.PrivateKeyID,
+			Bech32HRPSegwit:  params.Bech32HRPSegwit,
+		},
+		SegWit:         params.SegWit,
+		Taproot:        params.Taproot,
+		CashAddrPrefix: params.CashAddrPrefix,
+	}, nil
+}
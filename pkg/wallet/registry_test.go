@@ -0,0 +1,107 @@
+package wallet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTokenRegistry(t *testing.T) {
+	defer func() {
+		RegistryTokenMap = map[string]Token{}
+		delete(CosmosChainMap, "JUNO")
+	}()
+
+	dir := t.TempDir()
+	jsonFile := filepath.Join(dir, "tokens.json")
+	err := os.WriteFile(jsonFile, []byte(`{
+  "tokens": [
+    {"name": "ARB_ETH", "family": "evm", "curve": "secp256k1"},
+    {"name": "BTC_COPY", "family": "bitcoin", "curve": "secp256k1", "network": "BTC"}
+  ]
+}`), 0o600)
+	assert.NoError(t, err)
+	assert.NoError(t, LoadTokenRegistry(jsonFile))
+
+	yamlFile := filepath.Join(dir, "tokens.yaml")
+	err = os.WriteFile(yamlFile, []byte(`tokens:
+  - name: LTC_COPY
+    family: bitcoin
+    curve: secp256k1
+    params:
+      pub_key_hash_addr_id: 0x30
+      script_hash_addr_id: 0x32
+      private_key_id: 0xb0
+      bech32_hrp_segwit: ltc
+      segwit: true
+  - name: JUNO
+    family: cosmos
+    curve: secp256k1
+    hrp: juno
+  - name: SOL_COPY
+    family: solana
+    curve: ed25519
+`), 0o600)
+	assert.NoError(t, err)
+	assert.NoError(t, LoadTokenRegistry(yamlFile))
+
+	key, err := crypto.B58Deserialize("xpub6FXwXZ4feQjGX7ZXUdTB9cRuJuUJkzsWAQHejUBozkPgN9wwu7P7wNtuyRqiey52ES8PuZwmtgHHcVSFGH75RBthn8djN2fkdcbggtpRQQ2")
+	assert.NoError(t, err)
+	token, err := GetToken("ARB_ETH")
+	assert.NoError(t, err)
+	addresses, err := token.GenerateAddresses(key)
+	assert.NoError(t, err)
+	assert.True(t, foundAddress(addresses, "0xBe7f55D105BBacc2A963aef535d0d791D8911fB2", ""))
+
+	key, err = crypto.B58Deserialize("xpub6Gp6PCF54nmGyHJ4x3FhkwRnVGXFZkErWjYSgXHYpBKSERiTnmteAqHKDWq3VGDgE789RZ8x2fVArp9W6dQNw9HwNVHVGZYrbabSixYoAP4")
+	assert.NoError(t, err)
+	token, err = GetToken("BTC_COPY")
+	assert.NoError(t, err)
+	addresses, err = token.GenerateAddresses(key)
+	assert.NoError(t, err)
+	assert.True(t, foundAddress(addresses, "bc1qgkal4qh29fklm7rw07f6rw6mpxr8thjxvuc7hk", NATIVE_SEGWIT))
+
+	token, err = GetToken("LTC_COPY")
+	assert.NoError(t, err)
+	addresses, err = token.GenerateAddresses(key)
+	assert.NoError(t, err)
+	assert.Len(t, addresses, 3)
+	assert.True(t, foundAddress(addresses, "ltc1qgkal4qh29fklm7rw07f6rw6mpxr8thjxgqz60x", NATIVE_SEGWIT))
+
+	assert.Equal(t, CosmosChain{HRP: "juno"}, CosmosChainMap["JUNO"])
+	assert.NotContains(t, RegistryTokenMap, "JUNO")
+	_, err = GetToken("JUNO")
+	assert.NoError(t, err)
+
+	key, err = crypto.B58Deserialize("cpubGCmTMqXYTnzkbj4boYV9RcocrSYG1bSr8QuiRnEdhspzhvURRoBwV4iU7TnBKRRbmgHSAymckcRckZoNSR8SRK13n5ztB3pneN4xJSePBvG")
+	assert.NoError(t, err)
+	token, err = GetToken("SOL_COPY")
+	assert.NoError(t, err)
+	addresses, err = token.GenerateAddresses(key)
+	assert.NoError(t, err)
+	assert.True(t, foundAddress(addresses, "BbkWxpNVVPPyPgK8BCfmQDSPFNJFFKW6YvdKnS8ivng5", ""))
+}
+
+func TestTokenConfig(t *testing.T) {
+	_, err := (&TokenConfig{Name: "ETH", Family: FamilyEVM, Curve: "secp256k1"}).Token()
+	assert.Error(t, err)
+
+	_, err = (&TokenConfig{Name: "NEW_ETH", Family: "unknown", Curve: "secp256k1"}).Token()
+	assert.Error(t, err)
+
+	_, err = (&TokenConfig{Name: "NEW_ETH", Family: FamilyEVM, Curve: "ed25519"}).Token()
+	assert.Error(t, err)
+
+	_, err = (&TokenConfig{Name: "NEW_BTC", Family: FamilyBitcoin, Curve: "secp256k1"}).Token()
+	assert.Error(t, err)
+
+	_, err = (&TokenConfig{Name: "NEW_ATOM", Family: FamilyCosmos, Curve: "secp256k1"}).Token()
+	assert.Error(t, err)
+
+	token, err := (&TokenConfig{Name: "NEW_ETH", Family: FamilyEVM, Curve: "secp256k1"}).Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "NEW_ETH", token.Name)
+}
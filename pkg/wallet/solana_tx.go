@@ -0,0 +1,256 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/btcsuite/btcd/btcutil/base58"
+)
+
+// Solana program ids.
+const (
+	SolanaSystemProgramID = "11111111111111111111111111111111"
+	SolanaTokenProgramID  = "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"
+)
+
+const (
+	solanaSystemTransferInstruction       uint32 = 2
+	solanaTokenTransferCheckedInstruction byte   = 12
+)
+
+// SolanaTransfer is the JSON intent of a Solana transfer signed by the fee payer,
+// the fee payer is also the sender of lamports or the owner of SPL token source account.
+// Destination is the receiver wallet of lamports, or the destination token account of SPL transfer.
+type SolanaTransfer struct {
+	RecentBlockhash string               `json:"recent_blockhash"`
+	FeePayerPath    string               `json:"fee_payer_path"`
+	Destination     string               `json:"destination"`
+	Lamports        uint64               `json:"lamports,omitempty"`
+	SPL             *SolanaTokenTransfer `json:"spl,omitempty"`
+}
+
+// SolanaTokenTransfer is the SPL token TransferChecked of amount in base units from the source token account,
+// TokenProgram is the SPL token program by default.
+type SolanaTokenTransfer struct {
+	Source       string `json:"source"`
+	Mint         string `json:"mint"`
+	Amount       uint64 `json:"amount"`
+	Decimals     uint8  `json:"decimals"`
+	TokenProgram string `json:"token_program,omitempty"`
+}
+
+type solanaAccountMeta struct {
+	PublicKey  []byte
+	IsSigner   bool
+	IsWritable bool
+}
+
+type solanaInstruction struct {
+	ProgramID []byte
+	Accounts  []*solanaAccountMeta
+	Data      []byte
+}
+
+// SignSolanaTransfer builds the legacy transaction of transfer and signs it with the fee payer key,
+// returns the serialized transaction.
+func SignSolanaTransfer(key crypto.CKDKey, transfer *SolanaTransfer) ([]byte, error) {
+	if key == nil || transfer == nil {
+		return nil, fmt.Errorf("key or transfer is nil")
+	}
+	if key.GetType() != crypto.EDDSAKey || !key.IsPrivateKey() {
+		return nil, fmt.Errorf("key is not eddsa private key")
+	}
+	feePayer, err := eddsaPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	blockhash, err := decodeSolanaPublicKey("recent_blockhash", transfer.RecentBlockhash)
+	if err != nil {
+		return nil, err
+	}
+	destination, err := decodeSolanaPublicKey("destination", transfer.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	var instructions []*solanaInstruction
+	if transfer.SPL == nil {
+		if transfer.Lamports == 0 {
+			return nil, fmt.Errorf("lamports is zero")
+		}
+		instructions = solanaSystemTransfer(feePayer, destination, transfer.Lamports)
+	} else {
+		if transfer.Lamports != 0 {
+			return nil, fmt.Errorf("lamports and spl at same time is not allowed")
+		}
+		instructions, err = solanaTokenTransfer(feePayer, destination, transfer.SPL)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := compileSolanaMessage(feePayer, blockhash, instructions)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := crypto.SignEDDSA(key, message)
+	if err != nil {
+		return nil, err
+	}
+	tx := make([]byte, 0, 1+len(signature)+len(message))
+	tx = appendCompactU16(tx, 1)
+	tx = append(tx, signature...)
+	tx = append(tx, message...)
+	return tx, nil
+}
+
+func solanaSystemTransfer(from []byte, to []byte, lamports uint64) []*solanaInstruction {
+	data := binary.LittleEndian.AppendUint32(nil, solanaSystemTransferInstruction)
+	data = binary.LittleEndian.AppendUint64(data, lamports)
+	return []*solanaInstruction{
+		{
+			ProgramID: base58.Decode(SolanaSystemProgramID),
+			Accounts: []*solanaAccountMeta{
+				{PublicKey: from, IsSigner: true, IsWritable: true},
+				{PublicKey: to, IsWritable: true},
+			},
+			Data: data,
+		},
+	}
+}
+
+func solanaTokenTransfer(owner []byte, destination []byte, spl *SolanaTokenTransfer) ([]*solanaInstruction, error) {
+	if spl.Amount == 0 {
+		return nil, fmt.Errorf("spl amount is zero")
+	}
+	source, err := decodeSolanaPublicKey("spl source", spl.Source)
+	if err != nil {
+		return nil, err
+	}
+	mint, err := decodeSolanaPublicKey("spl mint", spl.Mint)
+	if err != nil {
+		return nil, err
+	}
+	tokenProgramID := spl.TokenProgram
+	if tokenProgramID == "" {
+		tokenProgramID = SolanaTokenProgramID
+	}
+	tokenProgram, err := decodeSolanaPublicKey("spl token_program", tokenProgramID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := []byte{solanaTokenTransferCheckedInstruction}
+	data = binary.LittleEndian.AppendUint64(data, spl.Amount)
+	data = append(data, spl.Decimals)
+	return []*solanaInstruction{
+		{
+			ProgramID: tokenProgram,
+			Accounts: []*solanaAccountMeta{
+				{PublicKey: source, IsWritable: true},
+				{PublicKey: mint},
+				{PublicKey: destination, IsWritable: true},
+				{PublicKey: owner, IsSigner: true},
+			},
+			Data: data,
+		},
+	}, nil
+}
+
+// compileSolanaMessage serializes the legacy message, accounts are ordered as
+// writable signers (fee payer first), readonly signers, writable non-signers and readonly non-signers.
+func compileSolanaMessage(feePayer []byte, blockhash []byte, instructions []*solanaInstruction) ([]byte, error) {
+	metas := []*solanaAccountMeta{{PublicKey: feePayer, IsSigner: true, IsWritable: true}}
+	for _, instruction := range instructions {
+		metas = append(metas, instruction.Accounts...)
+		metas = append(metas, &solanaAccountMeta{PublicKey: instruction.ProgramID})
+	}
+
+	// merge duplicated accounts, keep the first occurrence order
+	accounts := make([]*solanaAccountMeta, 0, len(metas))
+	for _, meta := range metas {
+		merged := false
+		for _, account := range accounts {
+			if bytes.Equal(account.PublicKey, meta.PublicKey) {
+				account.IsSigner = account.IsSigner || meta.IsSigner
+				account.IsWritable = account.IsWritable || meta.IsWritable
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			accounts = append(accounts, &solanaAccountMeta{PublicKey: meta.PublicKey, IsSigner: meta.IsSigner, IsWritable: meta.IsWritable})
+		}
+	}
+	ordered := make([]*solanaAccountMeta, 0, len(accounts))
+	var numSigners, numReadonlySigners, numReadonlyNonSigners byte
+	for _, group := range []struct{ signer, writable bool }{{true, true}, {true, false}, {false, true}, {false, false}} {
+		for _, account := range accounts {
+			if account.IsSigner != group.signer || account.IsWritable != group.writable {
+				continue
+			}
+			ordered = append(ordered, account)
+			switch {
+			case group.signer && group.writable:
+				numSigners++
+			case group.signer:
+				numSigners++
+				numReadonlySigners++
+			case !group.writable:
+				numReadonlyNonSigners++
+			}
+		}
+	}
+	if numSigners != 1 {
+		return nil, fmt.Errorf("signers %v not support, only fee payer signs", numSigners)
+	}
+
+	message := []byte{numSigners, numReadonlySigners, numReadonlyNonSigners}
+	message = appendCompactU16(message, len(ordered))
+	for _, account := range ordered {
+		message = append(message, account.PublicKey...)
+	}
+	message = append(message, blockhash...)
+	message = appendCompactU16(message, len(instructions))
+	for _, instruction := range instructions {
+		message = append(message, byte(solanaAccountIndex(ordered, instruction.ProgramID)))
+		message = appendCompactU16(message, len(instruction.Accounts))
+		for _, meta := range instruction.Accounts {
+			message = append(message, byte(solanaAccountIndex(ordered, meta.PublicKey)))
+		}
+		message = appendCompactU16(message, len(instruction.Data))
+		message = append(message, instruction.Data...)
+	}
+	return message, nil
+}
+
+func solanaAccountIndex(accounts []*solanaAccountMeta, publicKey []byte) int {
+	for i, account := range accounts {
+		if bytes.Equal(account.PublicKey, publicKey) {
+			return i
+		}
+	}
+	return -1
+}
+
+// appendCompactU16 appends Solana short vec length encoding.
+func appendCompactU16(b []byte, n int) []byte {
+	for {
+		elem := byte(n & 0x7f)
+		n >>= 7
+		if n == 0 {
+			return append(b, elem)
+		}
+		b = append(b, elem|0x80)
+	}
+}
+
+func decodeSolanaPublicKey(name string, s string) ([]byte, error) {
+	publicKey := base58.Decode(s)
+	if len(publicKey) != 32 {
+		return nil, fmt.Errorf("%v %v is invalid", name, s)
+	}
+	return publicKey, nil
+}
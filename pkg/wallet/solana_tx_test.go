@@ -0,0 +1,65 @@
+package wallet
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignSolanaTransfer(t *testing.T) {
+	root, err := crypto.B58Deserialize("cprv3NNjUWyx1RBi3H5V8GgxywS8GRLt6PntM2dkf8ZeRfmBukJ2iYs1fsoDcXeXGstHPH18FufK9z2KyRRpW2eh3MwhgHNd7VDCPuvU6pYsoig")
+	assert.NoError(t, err)
+	key, err := crypto.Derive(root, "m/44/501/0/0")
+	assert.NoError(t, err)
+	feePayer, err := eddsaPublicKey(key)
+	assert.NoError(t, err)
+
+	transfer := &SolanaTransfer{
+		RecentBlockhash: "4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T",
+		Destination:     "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM",
+		Lamports:        1000000,
+	}
+	tx, err := SignSolanaTransfer(key, transfer)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(1), tx[0])
+	message := tx[65:]
+	assert.Equal(t, "01000103c7a8e0390d224340fc5a96717a3a176080b4975cf2f94d753040659b8bdb054c"+
+		"7e8c088760bfde1dddcf32c17f209b8242ee52aaf131facd88d0ea2c6d0b06f2"+
+		"0000000000000000000000000000000000000000000000000000000000000000"+
+		"321cfa5add185e8893a5fd88013ec4d7e122ded46354cadff50d956395e75b60"+
+		"01020200010c0200000040420f0000000000", hex.EncodeToString(message))
+	assert.True(t, ed25519.Verify(feePayer, message, tx[1:65]))
+
+	transfer = &SolanaTransfer{
+		RecentBlockhash: "4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T",
+		Destination:     "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM",
+		SPL: &SolanaTokenTransfer{
+			Source:   "4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T",
+			Mint:     "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v",
+			Amount:   5000000,
+			Decimals: 6,
+		},
+	}
+	tx, err = SignSolanaTransfer(key, transfer)
+	assert.NoError(t, err)
+	message = tx[65:]
+	assert.Equal(t, "01000205c7a8e0390d224340fc5a96717a3a176080b4975cf2f94d753040659b8bdb054c"+
+		"321cfa5add185e8893a5fd88013ec4d7e122ded46354cadff50d956395e75b60"+
+		"7e8c088760bfde1dddcf32c17f209b8242ee52aaf131facd88d0ea2c6d0b06f2"+
+		"c6fa7af3bedbad3a3d65f36aabc97431b1bbe4c2d2f6e0e47ca60203452f5d61"+
+		"06ddf6e1d765a193d9cbe146ceeb79ac1cb485ed5f5b37913a8cf5857eff00a9"+
+		"321cfa5add185e8893a5fd88013ec4d7e122ded46354cadff50d956395e75b60"+
+		"010404010302000a0c404b4c000000000006", hex.EncodeToString(message))
+	assert.True(t, ed25519.Verify(feePayer, message, tx[1:65]))
+
+	transfer.Lamports = 1
+	_, err = SignSolanaTransfer(key, transfer)
+	assert.Error(t, err)
+	_, err = SignSolanaTransfer(key, &SolanaTransfer{RecentBlockhash: "invalid", Destination: transfer.Destination, Lamports: 1})
+	assert.Error(t, err)
+	_, err = SignSolanaTransfer(key.PublicKey(), transfer)
+	assert.Error(t, err)
+}
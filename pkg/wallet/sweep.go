@@ -0,0 +1,297 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// Sweep transaction is version 2 and its inputs signal replace-by-fee (BIP125),
+// so the fee can be bumped by a replacement if the fee rate is too low.
+const (
+	SweepTxVersion  = 2
+	SweepTxSequence = wire.MaxTxInSequenceNum - 2
+)
+
+// UTXO is an unspent output to sweep, Amount is in satoshi,
+// ScriptType is a short name in AddressTypeNames, such as native-segwit.
+// PrevTx is the optional raw transaction in hex which creates the output, Amount and script are checked with it.
+type UTXO struct {
+	TxID       string `json:"txid"`
+	Vout       uint32 `json:"vout"`
+	Amount     int64  `json:"amount"`
+	Path       string `json:"path"`
+	ScriptType string `json:"script_type"`
+	PrevTx     string `json:"prev_tx,omitempty"`
+}
+
+// SweepTransaction is a signed transaction spending all UTXOs to one destination.
+type SweepTransaction struct {
+	Tx    *wire.MsgTx
+	Fee   int64
+	VSize int64
+}
+
+type sweepInput struct {
+	privKey     *btcec.PrivateKey
+	addressType string
+	prevOut     *wire.TxOut
+}
+
+// BuildSweepTransaction builds and signs a transaction spending all UTXOs to the destination address,
+// fee is fee rate (satoshi per virtual byte) multiplied by the virtual size of the signed transaction.
+func BuildSweepTransaction(root crypto.CKDKey, params *chaincfg.Params, utxos []*UTXO,
+	destination string, feeRate int64,
+) (*SweepTransaction, error) {
+	if root == nil || params == nil {
+		return nil, fmt.Errorf("root key or network is nil")
+	}
+	if root.GetType() != crypto.ECDSAKey || !root.IsPrivateKey() {
+		return nil, fmt.Errorf("root key is not ecdsa private key")
+	}
+	if len(utxos) == 0 {
+		return nil, fmt.Errorf("utxos is nil")
+	}
+	if feeRate <= 0 {
+		return nil, fmt.Errorf("fee rate %v is invalid", feeRate)
+	}
+	destinationAddr, err := btcutil.DecodeAddress(destination, params)
+	if err != nil || !destinationAddr.IsForNet(params) {
+		return nil, fmt.Errorf("destination address %v is invalid for network %v", destination, params.Name)
+	}
+	destinationScript, err := txscript.PayToAddrScript(destinationAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := wire.NewMsgTx(SweepTxVersion)
+	inputs := make([]*sweepInput, 0, len(utxos))
+	prevOuts := make(map[wire.OutPoint]*wire.TxOut, len(utxos))
+	var total int64
+	for i, utxo := range utxos {
+		input, outPoint, err := sweepUTXOInput(root, params, utxo)
+		if err != nil {
+			return nil, fmt.Errorf("utxo %v: %v", i, err)
+		}
+		if _, exists := prevOuts[*outPoint]; exists {
+			return nil, fmt.Errorf("utxo %v: %v duplicated", i, outPoint)
+		}
+		prevOuts[*outPoint] = input.prevOut
+		inputs = append(inputs, input)
+		txIn := wire.NewTxIn(outPoint, nil, nil)
+		txIn.Sequence = SweepTxSequence
+		tx.AddTxIn(txIn)
+		total += utxo.Amount
+		if total > btcutil.MaxSatoshi {
+			return nil, fmt.Errorf("total amount of utxos exceeds max %v", btcutil.MaxSatoshi)
+		}
+	}
+	tx.AddTxOut(wire.NewTxOut(total, destinationScript))
+
+	vsize := estimateSweepVSize(tx, inputs)
+	fee := feeRate * vsize
+	if fee >= total {
+		return nil, fmt.Errorf("total amount %v of utxos is not enough for fee %v", total, fee)
+	}
+	tx.TxOut[0].Value = total - fee
+	if isDust(tx.TxOut[0]) {
+		return nil, fmt.Errorf("output amount %v after fee %v is dust", total-fee, fee)
+	}
+
+	prevOutFetcher := txscript.NewMultiPrevOutFetcher(prevOuts)
+	sigHashes := txscript.NewTxSigHashes(tx, prevOutFetcher)
+	for i, input := range inputs {
+		if err := signSweepInput(tx, sigHashes, prevOutFetcher, i, input); err != nil {
+			return nil, fmt.Errorf("sign input %v failed: %v", i, err)
+		}
+	}
+
+	// verify signed inputs by script engine before returning
+	for i, input := range inputs {
+		engine, err := txscript.NewEngine(input.prevOut.PkScript, tx, i, txscript.StandardVerifyFlags, nil,
+			sigHashes, input.prevOut.Value, prevOutFetcher)
+		if err != nil {
+			return nil, err
+		}
+		if err := engine.Execute(); err != nil {
+			return nil, fmt.Errorf("verify input %v failed: %v", i, err)
+		}
+	}
+	return &SweepTransaction{Tx: tx, Fee: fee, VSize: vsize}, nil
+}
+
+// sweepUTXOInput derives the key of utxo and builds the spent output from the address of script type.
+func sweepUTXOInput(root crypto.CKDKey, params *chaincfg.Params, utxo *UTXO) (*sweepInput, *wire.OutPoint, error) {
+	if utxo == nil {
+		return nil, nil, fmt.Errorf("utxo is nil")
+	}
+	if utxo.Amount <= 0 || utxo.Amount > btcutil.MaxSatoshi {
+		return nil, nil, fmt.Errorf("amount %v is invalid", utxo.Amount)
+	}
+	hash, err := chainhash.NewHashFromStr(utxo.TxID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("txid %v is invalid: %v", utxo.TxID, err)
+	}
+	addressType, exists := AddressTypeNames[utxo.ScriptType]
+	if !exists {
+		return nil, nil, fmt.Errorf("script type %v not support", utxo.ScriptType)
+	}
+
+	dk, err := crypto.Derive(root, utxo.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+	addresses, err := PubKeyToBTCAddr(dk, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	var pkScript []byte
+	for _, address := range addresses {
+		if address.Type != addressType {
+			continue
+		}
+		addr, err := btcutil.DecodeAddress(address.Address, params)
+		if err != nil {
+			return nil, nil, err
+		}
+		if pkScript, err = txscript.PayToAddrScript(addr); err != nil {
+			return nil, nil, err
+		}
+	}
+	if pkScript == nil {
+		return nil, nil, fmt.Errorf("script type %v not support", utxo.ScriptType)
+	}
+	if utxo.PrevTx != "" {
+		if err := checkPrevTxOut(utxo, hash, pkScript); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	privKey, _ := btcec.PrivKeyFromBytes(dk.GetKey())
+	return &sweepInput{
+		privKey:     privKey,
+		addressType: addressType,
+		prevOut:     wire.NewTxOut(utxo.Amount, pkScript),
+	}, wire.NewOutPoint(hash, utxo.Vout), nil
+}
+
+// checkPrevTxOut checks the utxo is the output of the previous transaction with the same amount and script,
+// the signature of segwit and taproot inputs commits to the amount, a wrong amount makes the transaction invalid.
+func checkPrevTxOut(utxo *UTXO, hash *chainhash.Hash, pkScript []byte) error {
+	prevTxBytes, err := hex.DecodeString(utxo.PrevTx)
+	if err != nil {
+		return fmt.Errorf("prev_tx is not hex: %v", err)
+	}
+	prevTx := wire.NewMsgTx(wire.TxVersion)
+	if err := prevTx.Deserialize(bytes.NewReader(prevTxBytes)); err != nil {
+		return fmt.Errorf("prev_tx is invalid: %v", err)
+	}
+	if prevTx.TxHash() != *hash {
+		return fmt.Errorf("prev_tx id %v is not txid %v", prevTx.TxHash(), hash)
+	}
+	if int(utxo.Vout) >= len(prevTx.TxOut) {
+		return fmt.Errorf("vout %v not found in prev_tx of %v outputs", utxo.Vout, len(prevTx.TxOut))
+	}
+	prevOut := prevTx.TxOut[utxo.Vout]
+	if prevOut.Value != utxo.Amount {
+		return fmt.Errorf("amount %v disagrees with value %v of output %v in prev_tx", utxo.Amount, prevOut.Value, utxo.Vout)
+	}
+	if !bytes.Equal(prevOut.PkScript, pkScript) {
+		return fmt.Errorf("output %v in prev_tx is not paid to %v address of path %v", utxo.Vout, utxo.ScriptType, utxo.Path)
+	}
+	return nil
+}
+
+// estimateSweepVSize returns virtual size of the transaction with the largest signatures of the input script types.
+func estimateSweepVSize(tx *wire.MsgTx, inputs []*sweepInput) int64 {
+	estimate := tx.Copy()
+	// DER signature with sighash type is at most 73 bytes, compressed public key is 33 bytes
+	ecdsaSig := make([]byte, 73)
+	pubKey := make([]byte, 33)
+	for i, input := range inputs {
+		txIn := estimate.TxIn[i]
+		switch input.addressType {
+		case LEGACY:
+			txIn.SignatureScript, _ = txscript.NewScriptBuilder().AddData(ecdsaSig).AddData(pubKey).Script()
+		case NESTED_SEGWIT:
+			txIn.SignatureScript, _ = txscript.NewScriptBuilder().AddData(make([]byte, 22)).Script()
+			txIn.Witness = wire.TxWitness{ecdsaSig, pubKey}
+		case NATIVE_SEGWIT:
+			txIn.Witness = wire.TxWitness{ecdsaSig, pubKey}
+		case TAPROOT, TAPROOT_BIP86:
+			// schnorr signature with default sighash type
+			txIn.Witness = wire.TxWitness{make([]byte, 64)}
+		}
+	}
+	weight := int64(estimate.SerializeSizeStripped()*3 + estimate.SerializeSize())
+	return (weight + 3) / 4
+}
+
+func signSweepInput(tx *wire.MsgTx, sigHashes *txscript.TxSigHashes, prevOutFetcher txscript.PrevOutputFetcher,
+	idx int, input *sweepInput,
+) error {
+	txIn := tx.TxIn[idx]
+	prevOut := input.prevOut
+	switch input.addressType {
+	case LEGACY:
+		sigScript, err := txscript.SignatureScript(tx, idx, prevOut.PkScript, txscript.SigHashAll, input.privKey, true)
+		if err != nil {
+			return err
+		}
+		txIn.SignatureScript = sigScript
+	case NATIVE_SEGWIT:
+		witness, err := txscript.WitnessSignature(tx, sigHashes, idx, prevOut.Value, prevOut.PkScript,
+			txscript.SigHashAll, input.privKey, true)
+		if err != nil {
+			return err
+		}
+		txIn.Witness = witness
+	case NESTED_SEGWIT:
+		redeemScript, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).
+			AddData(btcutil.Hash160(input.privKey.PubKey().SerializeCompressed())).Script()
+		if err != nil {
+			return err
+		}
+		witness, err := txscript.WitnessSignature(tx, sigHashes, idx, prevOut.Value, redeemScript,
+			txscript.SigHashAll, input.privKey, true)
+		if err != nil {
+			return err
+		}
+		sigScript, err := txscript.NewScriptBuilder().AddData(redeemScript).Script()
+		if err != nil {
+			return err
+		}
+		txIn.SignatureScript = sigScript
+		txIn.Witness = witness
+	case TAPROOT, TAPROOT_BIP86:
+		sig, err := taprootKeySpendSignature(tx, sigHashes, prevOutFetcher, idx, prevOut, txscript.SigHashDefault, input.privKey)
+		if err != nil {
+			return err
+		}
+		txIn.Witness = wire.TxWitness{sig}
+	default:
+		return fmt.Errorf("address type %v not support", input.addressType)
+	}
+	return nil
+}
+
+// isDust reports whether the output value is less than the fee of spending it at 3 satoshi per byte,
+// which is the default dust relay fee rate of bitcoin core, such as 546 for p2pkh and 294 for p2wpkh.
+func isDust(txOut *wire.TxOut) bool {
+	// outpoint, sequence and script length, with a signature and compressed public key
+	size := txOut.SerializeSize() + 32 + 4 + 1 + 4
+	if txscript.IsWitnessProgram(txOut.PkScript) {
+		size += 107 / 4
+	} else {
+		size += 107
+	}
+	return txOut.Value < int64(size)*3
+}
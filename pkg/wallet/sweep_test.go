@@ -0,0 +1,105 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildSweepTransaction(t *testing.T) {
+	root, err := crypto.B58Deserialize("xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi")
+	assert.NoError(t, err)
+
+	txID := "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b"
+	utxos := []*UTXO{
+		{TxID: txID, Vout: 0, Amount: 100000, Path: "m/44'/0'/0'/0/0", ScriptType: "legacy"},
+		{TxID: txID, Vout: 1, Amount: 200000, Path: "m/49'/0'/0'/0/0", ScriptType: "nested-segwit"},
+		{TxID: txID, Vout: 2, Amount: 300000, Path: "m/84'/0'/0'/0/0", ScriptType: "native-segwit"},
+		{TxID: txID, Vout: 3, Amount: 400000, Path: "m/86'/0'/0'/0/0", ScriptType: "taproot-bip86"},
+		{TxID: txID, Vout: 4, Amount: 500000, Path: "m/86'/0'/0'/0/1", ScriptType: "taproot"},
+	}
+	destination := "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq"
+	sweep, err := BuildSweepTransaction(root, &chaincfg.MainNetParams, utxos, destination, 10)
+	assert.NoError(t, err)
+	assert.Len(t, sweep.Tx.TxIn, 5)
+	assert.Len(t, sweep.Tx.TxOut, 1)
+	assert.Equal(t, int32(2), sweep.Tx.Version)
+	for _, txIn := range sweep.Tx.TxIn {
+		// replaceable by BIP125, sequence below 0xfffffffe
+		assert.Equal(t, uint32(0xfffffffd), txIn.Sequence)
+	}
+	assert.Equal(t, sweep.VSize*10, sweep.Fee)
+	assert.Equal(t, int64(1500000)-sweep.Fee, sweep.Tx.TxOut[0].Value)
+
+	// estimated virtual size covers the signed transaction
+	signedVSize := int64(sweep.Tx.SerializeSizeStripped()*3+sweep.Tx.SerializeSize()+3) / 4
+	assert.LessOrEqual(t, signedVSize, sweep.VSize)
+	assert.LessOrEqual(t, sweep.VSize-signedVSize, int64(5))
+
+	_, err = BuildSweepTransaction(root, &chaincfg.TestNet3Params, utxos, destination, 10)
+	assert.Error(t, err)
+	_, err = BuildSweepTransaction(root, &chaincfg.MainNetParams, utxos[:1], destination, 1000)
+	assert.Error(t, err)
+	_, err = BuildSweepTransaction(root, &chaincfg.MainNetParams, append(utxos, utxos[0]), destination, 10)
+	assert.Error(t, err)
+	_, err = BuildSweepTransaction(root, &chaincfg.MainNetParams,
+		[]*UTXO{{TxID: txID, Amount: 100000, Path: "m/44'/0'/0'/0/0", ScriptType: "cashaddr"}}, destination, 10)
+	assert.Error(t, err)
+	_, err = BuildSweepTransaction(root.PublicKey(), &chaincfg.MainNetParams, utxos, destination, 10)
+	assert.Error(t, err)
+}
+
+func TestBuildSweepTransactionPrevTx(t *testing.T) {
+	root, err := crypto.B58Deserialize("xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi")
+	assert.NoError(t, err)
+	dk, err := crypto.Derive(root, "m/84'/0'/0'/0/0")
+	assert.NoError(t, err)
+	addresses, err := PubKeyToBTCAddr(dk, &chaincfg.MainNetParams)
+	assert.NoError(t, err)
+	var pkScript []byte
+	for _, address := range addresses {
+		if address.Type == NATIVE_SEGWIT {
+			addr, err := btcutil.DecodeAddress(address.Address, &chaincfg.MainNetParams)
+			assert.NoError(t, err)
+			pkScript, err = txscript.PayToAddrScript(addr)
+			assert.NoError(t, err)
+		}
+	}
+
+	prevTx := wire.NewMsgTx(wire.TxVersion)
+	prevTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+	prevTx.AddTxOut(wire.NewTxOut(300000, pkScript))
+	prevTx.AddTxOut(wire.NewTxOut(400000, []byte{txscript.OP_TRUE}))
+	var buf bytes.Buffer
+	assert.NoError(t, prevTx.Serialize(&buf))
+	utxo := &UTXO{TxID: prevTx.TxHash().String(), Vout: 0, Amount: 300000, Path: "m/84'/0'/0'/0/0",
+		ScriptType: "native-segwit", PrevTx: hex.EncodeToString(buf.Bytes())}
+	destination := "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq"
+	_, err = BuildSweepTransaction(root, &chaincfg.MainNetParams, []*UTXO{utxo}, destination, 10)
+	assert.NoError(t, err)
+
+	utxo.Amount = 310000
+	_, err = BuildSweepTransaction(root, &chaincfg.MainNetParams, []*UTXO{utxo}, destination, 10)
+	assert.ErrorContains(t, err, "amount 310000 disagrees with value 300000")
+	utxo.Amount, utxo.Vout = 400000, 1
+	_, err = BuildSweepTransaction(root, &chaincfg.MainNetParams, []*UTXO{utxo}, destination, 10)
+	assert.ErrorContains(t, err, "is not paid to native-segwit address")
+	utxo.Vout = 2
+	_, err = BuildSweepTransaction(root, &chaincfg.MainNetParams, []*UTXO{utxo}, destination, 10)
+	assert.ErrorContains(t, err, "vout 2 not found")
+	utxo.Vout, utxo.Amount, utxo.TxID = 0, 300000, "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b"
+	_, err = BuildSweepTransaction(root, &chaincfg.MainNetParams, []*UTXO{utxo}, destination, 10)
+	assert.ErrorContains(t, err, "is not txid")
+
+	utxo.PrevTx = ""
+	_, err = BuildSweepTransaction(root, &chaincfg.MainNetParams, []*UTXO{utxo}, destination, 10000)
+	assert.ErrorContains(t, err, "not enough for fee")
+}
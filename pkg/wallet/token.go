@@ -6,20 +6,25 @@ import (
 	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
 )
 
+// Token generates addresses of the derived child key,
+// EVM marks tokens of EVM address whose child private keys can be exported in keystore, including Ethermint chains.
 type Token struct {
 	Name              string
 	GenerateAddresses func(key crypto.CKDKey) ([]Address, error)
+	EVM               bool
 }
 
 var (
 	SMNT_MNT = Token{
 		Name:              "SMNT_MNT",
 		GenerateAddresses: GenerateEVMAddress,
+		EVM:               true,
 	}
 
 	MNT = Token{
 		Name:              "MNT",
 		GenerateAddresses: GenerateEVMAddress,
+		EVM:               true,
 	}
 
 	XTN = Token{
@@ -32,14 +37,71 @@ var (
 		GenerateAddresses: GenerateBTCAddresses,
 	}
 
+	LTC = Token{
+		Name:              "LTC",
+		GenerateAddresses: GenerateLTCAddresses,
+	}
+
+	DOGE = Token{
+		Name:              "DOGE",
+		GenerateAddresses: GenerateDOGEAddresses,
+	}
+
+	BCH = Token{
+		Name:              "BCH",
+		GenerateAddresses: GenerateBCHAddresses,
+	}
+
 	SETH = Token{
 		Name:              "SETH",
 		GenerateAddresses: GenerateEVMAddress,
+		EVM:               true,
 	}
 
 	ETH = Token{
 		Name:              "ETH",
 		GenerateAddresses: GenerateEVMAddress,
+		EVM:               true,
+	}
+
+	TRX = Token{
+		Name:              "TRX",
+		GenerateAddresses: GenerateTRXAddress,
+	}
+
+	XRP = Token{
+		Name:              "XRP",
+		GenerateAddresses: GenerateXRPAddress,
+	}
+
+	SOL = Token{
+		Name:              "SOL",
+		GenerateAddresses: GenerateSOLAddress,
+	}
+
+	APT = Token{
+		Name:              "APT",
+		GenerateAddresses: GenerateAPTAddress,
+	}
+
+	SUI = Token{
+		Name:              "SUI",
+		GenerateAddresses: GenerateSUIAddress,
+	}
+
+	XLM = Token{
+		Name:              "XLM",
+		GenerateAddresses: GenerateXLMAddress,
+	}
+
+	ALGO = Token{
+		Name:              "ALGO",
+		GenerateAddresses: GenerateALGOAddress,
+	}
+
+	NEAR = Token{
+		Name:              "NEAR",
+		GenerateAddresses: GenerateNEARAddress,
 	}
 )
 
@@ -48,13 +110,61 @@ var TokenMap = map[string]Token{
 	MNT.Name:      MNT,
 	XTN.Name:      XTN,
 	BTC.Name:      BTC,
+	LTC.Name:      LTC,
+	DOGE.Name:     DOGE,
+	BCH.Name:      BCH,
 	SETH.Name:     SETH,
 	ETH.Name:      ETH,
+	TRX.Name:      TRX,
+	XRP.Name:      XRP,
+	SOL.Name:      SOL,
+	APT.Name:      APT,
+	SUI.Name:      SUI,
+	XLM.Name:      XLM,
+	ALGO.Name:     ALGO,
+	NEAR.Name:     NEAR,
+}
+
+// CosmosChain defines a Cosmos SDK chain by its bech32 human-readable prefix.
+type CosmosChain struct {
+	HRP       string
+	Ethermint bool
+}
+
+var CosmosChainMap = map[string]CosmosChain{
+	"ATOM":  {HRP: "cosmos"},
+	"OSMO":  {HRP: "osmo"},
+	"TIA":   {HRP: "celestia"},
+	"INJ":   {HRP: "inj", Ethermint: true},
+	"EVMOS": {HRP: "evmos", Ethermint: true},
+}
+
+// RegisterCosmosChain registers human-readable prefix of a Cosmos SDK chain token,
+// cosmos family tokens of token registry file are registered by it.
+func RegisterCosmosChain(name string, chain CosmosChain) {
+	CosmosChainMap[name] = chain
+}
+
+func NewCosmosToken(name string, chain CosmosChain) Token {
+	return Token{
+		Name: name,
+		GenerateAddresses: func(key crypto.CKDKey) ([]Address, error) {
+			return PubKeyToCosmosAddr(key, chain.HRP, chain.Ethermint)
+		},
+		// Ethermint accounts are keccak addresses of eth_secp256k1 keys, same as EVM
+		EVM: chain.Ethermint,
+	}
 }
 
 func GetToken(name string) (Token, error) {
 	if token, exists := TokenMap[name]; exists {
 		return token, nil
 	}
+	if chain, exists := CosmosChainMap[name]; exists {
+		return NewCosmosToken(name, chain), nil
+	}
+	if token, exists := RegistryTokenMap[name]; exists {
+		return token, nil
+	}
 	return Token{}, fmt.Errorf("token %v not support", name)
 }
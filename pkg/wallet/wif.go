@@ -0,0 +1,33 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/crypto"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+)
+
+// WIFNetworkMap contains networks of tokens whose child private keys are exported in WIF.
+var WIFNetworkMap = map[string]*Network{
+	BTC.Name:  &BTCNetwork,
+	XTN.Name:  &XTNNetwork,
+	LTC.Name:  &LTCNetwork,
+	DOGE.Name: &DOGENetwork,
+}
+
+// EncodeWIF encodes the private key in wallet import format of compressed public key with network prefix.
+func EncodeWIF(key crypto.CKDKey, network *Network) (string, error) {
+	if key == nil || network == nil || network.Params == nil {
+		return "", fmt.Errorf("network or key is nil")
+	}
+	if key.GetType() != crypto.ECDSAKey || !key.IsPrivateKey() {
+		return "", fmt.Errorf("key is not ecdsa private key")
+	}
+	privKey, _ := btcec.PrivKeyFromBytes(key.GetKey())
+	wif, err := btcutil.NewWIF(privKey, network.Params, true)
+	if err != nil {
+		return "", err
+	}
+	return wif.String(), nil
+}
@@ -0,0 +1,30 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/CoboGlobal/cobo-mpc-recovery-kits/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeWIF(t *testing.T) {
+	d, err := utils.Decode("0x0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d")
+	assert.NoError(t, err)
+	key := testPrivateKey(t, d)
+
+	expected := map[string]string{
+		"BTC":  "KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP98617",
+		"XTN":  "cMzLdeGd5vEqxB8B6VFQoRopQ3sLAAvEzDAoQgvX54xwofSWj1fx",
+		"LTC":  "T3TccUZx4EXBZaHnFiP9eTr8igDEZoqSjNvbA56Z8vV74oyAcjTK",
+		"DOGE": "QP2GKa5kuU2i2G3xJMH5KL9NErbVYGxMoRiF5trrJJvHzrJ2Ebp7",
+	}
+	assert.Len(t, WIFNetworkMap, len(expected))
+	for name, wif := range expected {
+		encoded, err := EncodeWIF(key, WIFNetworkMap[name])
+		assert.NoError(t, err)
+		assert.Equal(t, wif, encoded, name)
+	}
+
+	_, err = EncodeWIF(key.PublicKey(), &BTCNetwork)
+	assert.Error(t, err)
+}